@@ -0,0 +1,489 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Argument flags for the one-shot call and prompt commands
+	oneShotArgs     []string
+	oneShotJSON     string
+	oneShotJSONFile string
+)
+
+// addArgumentFlags registers the flags used to pass arguments to one-shot commands
+func addArgumentFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&oneShotArgs, "arg", nil, "Argument as key=value (can be repeated)")
+	cmd.Flags().StringVar(&oneShotJSON, "json", "", "Arguments as a JSON object")
+	cmd.Flags().StringVar(&oneShotJSONFile, "json-file", "", "Read the arguments JSON object from a file (\"-\" for stdin)")
+}
+
+// readJSONArguments decodes the arguments given with --json or --json-file
+func readJSONArguments(jsonArgs, jsonFile string) (map[string]any, error) {
+	if jsonArgs != "" && jsonFile != "" {
+		return nil, fmt.Errorf("--json and --json-file cannot be used together")
+	}
+
+	data := []byte(jsonArgs)
+	if jsonFile != "" {
+		var err error
+		if jsonFile == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(jsonFile)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JSON arguments: %w", err)
+		}
+	}
+
+	arguments := make(map[string]any)
+	if strings.TrimSpace(string(data)) == "" {
+		return arguments, nil
+	}
+	if err := json.Unmarshal(data, &arguments); err != nil {
+		return nil, fmt.Errorf("invalid JSON arguments: %w", err)
+	}
+
+	return arguments, nil
+}
+
+// applyArgumentFlags merges key=value pairs given with --arg into the JSON
+// arguments, converting values to the types declared in the tool schema
+func applyArgumentFlags(schema mcp.ToolInputSchema, arguments map[string]any, pairs []string) (map[string]any, error) {
+	for _, pair := range pairs {
+		key, value, found := strings.Cut(pair, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid argument %q, expected key=value", pair)
+		}
+
+		converted, err := convertArgumentValue(schema.Properties[key], value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %q: %w", key, err)
+		}
+		arguments[key] = converted
+	}
+
+	if missing := missingRequiredArguments(schema, arguments); len(missing) > 0 {
+		return nil, fmt.Errorf("missing required arguments: %s", strings.Join(missing, ", "))
+	}
+
+	return arguments, nil
+}
+
+// promptArguments builds prompt arguments, which are always strings, from
+// the JSON arguments and --arg key=value pairs
+func promptArguments(base map[string]any, pairs []string) (map[string]string, error) {
+	arguments := make(map[string]string, len(base)+len(pairs))
+	for key, value := range base {
+		if s, ok := value.(string); ok {
+			arguments[key] = s
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %q: %w", key, err)
+		}
+		arguments[key] = string(encoded)
+	}
+
+	for _, pair := range pairs {
+		key, value, found := strings.Cut(pair, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid argument %q, expected key=value", pair)
+		}
+		arguments[key] = value
+	}
+
+	return arguments, nil
+}
+
+// parsePromptArguments converts command line style arguments into prompt
+// arguments, checked against the arguments declared by the prompt. Values
+// are given as key=value pairs, or positionally for the required arguments
+// in the order they are declared (values containing '=' must use key=value).
+func parsePromptArguments(prompt mcp.Prompt, args []string) (map[string]string, error) {
+	declared := make(map[string]bool, len(prompt.Arguments))
+	var required []string
+	for _, argument := range prompt.Arguments {
+		declared[argument.Name] = true
+		if argument.Required {
+			required = append(required, argument.Name)
+		}
+	}
+
+	arguments := make(map[string]string, len(args))
+	positional := 0
+	for _, arg := range args {
+		if key, value, found := strings.Cut(arg, "="); found && key != "" {
+			if !declared[key] {
+				return nil, fmt.Errorf("unknown argument %q for prompt %s", key, prompt.Name)
+			}
+			arguments[key] = value
+			continue
+		}
+
+		for positional < len(required) {
+			if _, exists := arguments[required[positional]]; !exists {
+				break
+			}
+			positional++
+		}
+		if positional >= len(required) {
+			return nil, fmt.Errorf("unexpected positional argument %q (use key=value for optional arguments)", arg)
+		}
+		arguments[required[positional]] = arg
+		positional++
+	}
+
+	var missing []string
+	for _, name := range required {
+		if _, ok := arguments[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required arguments: %s", strings.Join(missing, ", "))
+	}
+
+	return arguments, nil
+}
+
+// parseToolArguments converts command line style arguments into a tool
+// arguments object using the tool's input schema.
+//
+// Arguments can be given as:
+//   - positional values, assigned to the required properties in order
+//   - key=value pairs, converted to the declared property type
+//   - a single inline JSON object holding all (or some) of the arguments
+func parseToolArguments(schema mcp.ToolInputSchema, args []string) (map[string]any, error) {
+	arguments := make(map[string]any)
+	positional := 0
+
+	for _, arg := range args {
+		// Inline JSON object with several arguments at once
+		if object, ok := argumentsObject(schema, arg); ok {
+			for key, value := range object {
+				arguments[key] = value
+			}
+			continue
+		}
+
+		if key, value, ok := splitKeyValue(schema, arg); ok {
+			converted, err := convertArgumentValue(schema.Properties[key], value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %q: %w", key, err)
+			}
+			arguments[key] = converted
+			continue
+		}
+
+		// Positional argument: assign to the next required property not set yet
+		for positional < len(schema.Required) {
+			if _, exists := arguments[schema.Required[positional]]; !exists {
+				break
+			}
+			positional++
+		}
+		if positional >= len(schema.Required) {
+			return nil, fmt.Errorf("unexpected positional argument %q (use key=value for optional arguments)", arg)
+		}
+
+		key := schema.Required[positional]
+		converted, err := convertArgumentValue(schema.Properties[key], arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %q: %w", key, err)
+		}
+		arguments[key] = converted
+		positional++
+	}
+
+	if missing := missingRequiredArguments(schema, arguments); len(missing) > 0 {
+		return nil, fmt.Errorf("missing required arguments: %s", strings.Join(missing, ", "))
+	}
+
+	return arguments, nil
+}
+
+// argumentsObject detects an inline JSON object holding tool arguments. An
+// object whose keys are not all declared properties is treated as a
+// positional value instead (e.g. for a required object property).
+func argumentsObject(schema mcp.ToolInputSchema, arg string) (map[string]any, bool) {
+	if !strings.HasPrefix(strings.TrimSpace(arg), "{") {
+		return nil, false
+	}
+
+	var object map[string]any
+	if err := json.Unmarshal([]byte(arg), &object); err != nil {
+		return nil, false
+	}
+
+	if len(schema.Properties) > 0 {
+		for key := range object {
+			if _, declared := schema.Properties[key]; !declared {
+				return nil, false
+			}
+		}
+	}
+	return object, true
+}
+
+// splitKeyValue detects key=value arguments. When the schema declares
+// properties, only those keys are accepted so that positional values
+// containing '=' (URLs, expressions) are not misinterpreted.
+func splitKeyValue(schema mcp.ToolInputSchema, arg string) (string, string, bool) {
+	key, value, found := strings.Cut(arg, "=")
+	if !found || key == "" {
+		return "", "", false
+	}
+
+	if len(schema.Properties) > 0 {
+		_, declared := schema.Properties[key]
+		return key, value, declared
+	}
+
+	for _, r := range key {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' && r != '.' {
+			return "", "", false
+		}
+	}
+	return key, value, true
+}
+
+// convertArgumentValue converts a raw string into the JSON type declared by
+// a property schema
+func convertArgumentValue(property any, value string) (any, error) {
+	propertySchema, _ := property.(map[string]any)
+
+	for _, propertyType := range schemaTypes(propertySchema) {
+		switch propertyType {
+		case "string":
+			return value, nil
+		case "integer":
+			if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return n, nil
+			}
+		case "number":
+			if n, err := strconv.ParseFloat(value, 64); err == nil {
+				return n, nil
+			}
+		case "boolean":
+			if b, err := strconv.ParseBool(value); err == nil {
+				return b, nil
+			}
+		case "null":
+			if value == "null" {
+				return nil, nil
+			}
+		case "array":
+			if items, err := convertArrayValue(propertySchema, value); err == nil {
+				return items, nil
+			}
+		case "object":
+			var object map[string]any
+			if err := json.Unmarshal([]byte(value), &object); err == nil {
+				return object, nil
+			}
+		}
+	}
+
+	types := schemaTypes(propertySchema)
+	if len(types) == 0 {
+		// Untyped property: accept inline JSON values, otherwise keep the string
+		var decoded any
+		if looksLikeJSON(value) && json.Unmarshal([]byte(value), &decoded) == nil {
+			return decoded, nil
+		}
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("%q is not a valid %s", value, strings.Join(types, " or "))
+}
+
+// convertArrayValue accepts either a JSON array or a comma-separated list
+// whose items are converted using the "items" schema
+func convertArrayValue(propertySchema map[string]any, value string) ([]any, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "[") {
+		var items []any
+		if err := json.Unmarshal([]byte(value), &items); err != nil {
+			return nil, err
+		}
+		return items, nil
+	}
+
+	items := []any{}
+	if value == "" {
+		return items, nil
+	}
+	for _, part := range strings.Split(value, ",") {
+		item, err := convertArgumentValue(propertySchema["items"], strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// schemaTypes returns the declared JSON types of a property schema
+func schemaTypes(propertySchema map[string]any) []string {
+	switch t := propertySchema["type"].(type) {
+	case string:
+		return []string{t}
+	case []any:
+		var types []string
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	case []string:
+		return t
+	}
+	return nil
+}
+
+func looksLikeJSON(value string) bool {
+	value = strings.TrimSpace(value)
+	return strings.HasPrefix(value, "{") || strings.HasPrefix(value, "[")
+}
+
+// missingRequiredArguments returns the required properties not present in arguments
+func missingRequiredArguments(schema mcp.ToolInputSchema, arguments map[string]any) []string {
+	var missing []string
+	for _, name := range schema.Required {
+		if _, ok := arguments[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// toolUsage builds a usage line for a tool from its input schema
+func toolUsage(tool mcp.Tool) string {
+	var b strings.Builder
+	b.WriteString("call ")
+	b.WriteString(tool.Name)
+
+	required := make(map[string]bool, len(tool.InputSchema.Required))
+	for _, name := range tool.InputSchema.Required {
+		required[name] = true
+		fmt.Fprintf(&b, " <%s>", name)
+	}
+
+	var optional []string
+	for name := range tool.InputSchema.Properties {
+		if !required[name] {
+			optional = append(optional, name)
+		}
+	}
+	sort.Strings(optional)
+	for _, name := range optional {
+		fmt.Fprintf(&b, " [%s=<value>]", name)
+	}
+
+	return b.String()
+}
+
+// promptUsage builds a usage line for a prompt from its declared arguments
+func promptUsage(prompt mcp.Prompt) string {
+	var b strings.Builder
+	b.WriteString("prompt ")
+	b.WriteString(prompt.Name)
+	for _, argument := range prompt.Arguments {
+		if argument.Required {
+			fmt.Fprintf(&b, " <%s>", argument.Name)
+		}
+	}
+	for _, argument := range prompt.Arguments {
+		if !argument.Required {
+			fmt.Fprintf(&b, " [%s=<value>]", argument.Name)
+		}
+	}
+	return b.String()
+}
+
+// splitCommandLine splits an interactive command line into words. Single and
+// double quotes group words, backslashes escape the next character and
+// braces or brackets are kept together so inline JSON can contain spaces.
+// Braces or brackets left open are an error rather than the rest of the line
+// becoming one word.
+func splitCommandLine(line string) ([]string, error) {
+	var (
+		words   []string
+		current strings.Builder
+		inWord  bool
+		quote   rune
+		depth   int
+		escaped bool
+	)
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && (quote != '\'' || depth > 0):
+			if depth > 0 {
+				// Keep escapes inside JSON intact for JSON decoding
+				current.WriteRune(r)
+			}
+			escaped = true
+			inWord = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+				if depth > 0 {
+					current.WriteRune(r)
+				}
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inWord = true
+			if depth > 0 {
+				current.WriteRune(r)
+			}
+		case r == '{' || r == '[':
+			depth++
+			current.WriteRune(r)
+			inWord = true
+		case (r == '}' || r == ']') && depth > 0:
+			depth--
+			current.WriteRune(r)
+		case unicode.IsSpace(r) && depth == 0:
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("unclosed brace or bracket: %w", io.ErrUnexpectedEOF)
+	}
+	if inWord {
+		words = append(words, current.String())
+	}
+
+	return words, nil
+}
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testToolSchema() mcp.ToolInputSchema {
+	return mcp.ToolInputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"path":    map[string]any{"type": "string"},
+			"count":   map[string]any{"type": "integer"},
+			"ratio":   map[string]any{"type": "number"},
+			"dry_run": map[string]any{"type": "boolean"},
+			"tags":    map[string]any{"type": "array", "items": map[string]any{"type": "integer"}},
+			"options": map[string]any{"type": "object"},
+		},
+		Required: []string{"path", "count"},
+	}
+}
+
+func TestParseToolArguments(t *testing.T) {
+	schema := testToolSchema()
+
+	t.Run("positional arguments fill required properties in order", func(t *testing.T) {
+		args, err := parseToolArguments(schema, []string{"/tmp/file", "3"})
+		require.NoError(t, err)
+		assert.Equal(t, "/tmp/file", args["path"])
+		assert.Equal(t, int64(3), args["count"])
+	})
+
+	t.Run("key=value pairs are converted to declared types", func(t *testing.T) {
+		args, err := parseToolArguments(schema, []string{
+			"path=a=b", "count=2", "ratio=0.5", "dry_run=true", "tags=1,2,3", `options={"deep": {"x": 1}}`,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "a=b", args["path"])
+		assert.Equal(t, int64(2), args["count"])
+		assert.Equal(t, 0.5, args["ratio"])
+		assert.Equal(t, true, args["dry_run"])
+		assert.Equal(t, []any{int64(1), int64(2), int64(3)}, args["tags"])
+		assert.Equal(t, map[string]any{"deep": map[string]any{"x": float64(1)}}, args["options"])
+	})
+
+	t.Run("JSON array values", func(t *testing.T) {
+		args, err := parseToolArguments(schema, []string{"x", "1", "tags=[4, 5]"})
+		require.NoError(t, err)
+		assert.Equal(t, []any{float64(4), float64(5)}, args["tags"])
+	})
+
+	t.Run("mixed positional and named arguments", func(t *testing.T) {
+		args, err := parseToolArguments(schema, []string{"count=7", "/tmp/file"})
+		require.NoError(t, err)
+		assert.Equal(t, "/tmp/file", args["path"])
+		assert.Equal(t, int64(7), args["count"])
+	})
+
+	t.Run("inline JSON object with all arguments", func(t *testing.T) {
+		args, err := parseToolArguments(schema, []string{`{"path": "p", "count": 1}`})
+		require.NoError(t, err)
+		assert.Equal(t, "p", args["path"])
+		assert.Equal(t, float64(1), args["count"])
+	})
+
+	t.Run("missing required arguments are reported", func(t *testing.T) {
+		_, err := parseToolArguments(schema, []string{"dry_run=false"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing required arguments: path, count")
+	})
+
+	t.Run("invalid typed values are rejected", func(t *testing.T) {
+		_, err := parseToolArguments(schema, []string{"x", "many"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid value for "count"`)
+	})
+
+	t.Run("too many positional arguments", func(t *testing.T) {
+		_, err := parseToolArguments(schema, []string{"x", "1", "extra"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unexpected positional argument")
+	})
+
+	t.Run("schema without properties accepts any key", func(t *testing.T) {
+		args, err := parseToolArguments(mcp.ToolInputSchema{Type: "object"}, []string{"a=1", "b=[1]"})
+		require.NoError(t, err)
+		assert.Equal(t, "1", args["a"])
+		assert.Equal(t, []any{float64(1)}, args["b"])
+	})
+}
+
+func TestToolUsage(t *testing.T) {
+	tool := mcp.Tool{Name: "copy", InputSchema: testToolSchema()}
+	assert.Equal(t,
+		"call copy <path> <count> [dry_run=<value>] [options=<value>] [ratio=<value>] [tags=<value>]",
+		toolUsage(tool))
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"call echo hello", []string{"call", "echo", "hello"}},
+		{`call echo "hello world"`, []string{"call", "echo", "hello world"}},
+		{`call echo 'it is'`, []string{"call", "echo", "it is"}},
+		{`call x opts={"a": [1, 2], "b": "c d"}`, []string{"call", "x", `opts={"a": [1, 2], "b": "c d"}`}},
+		{`call x '{"a": 1}'`, []string{"call", "x", `{"a": 1}`}},
+		{`call x {"a": "q\"uote"}`, []string{"call", "x", `{"a": "q\"uote"}`}},
+		{`call x a\ b`, []string{"call", "x", "a b"}},
+		{"   ", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			words, err := splitCommandLine(test.input)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, words)
+		})
+	}
+
+	t.Run("unterminated quote", func(t *testing.T) {
+		_, err := splitCommandLine(`call x "oops`)
+		assert.Error(t, err)
+	})
+
+	t.Run("unclosed bracket", func(t *testing.T) {
+		for _, input := range []string{`call x {"a": [1, 2}`, `call x opts={"a": 1} tags=[1`} {
+			_, err := splitCommandLine(input)
+			assert.ErrorIs(t, err, io.ErrUnexpectedEOF, input)
+			assert.ErrorContains(t, err, "unclosed brace or bracket", input)
+		}
+	})
+}
+
+func TestReadJSONArguments(t *testing.T) {
+	t.Run("inline JSON", func(t *testing.T) {
+		args, err := readJSONArguments(`{"a": 1, "b": "x"}`, "")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"a": float64(1), "b": "x"}, args)
+	})
+
+	t.Run("JSON file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "args.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"path": "/tmp"}`), 0o600))
+
+		args, err := readJSONArguments("", path)
+		require.NoError(t, err)
+		assert.Equal(t, "/tmp", args["path"])
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		args, err := readJSONArguments("", "")
+		require.NoError(t, err)
+		assert.Empty(t, args)
+	})
+
+	t.Run("both flags", func(t *testing.T) {
+		_, err := readJSONArguments("{}", "args.json")
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := readJSONArguments("[1, 2]", "")
+		assert.Error(t, err)
+	})
+}
+
+func TestApplyArgumentFlags(t *testing.T) {
+	schema := testToolSchema()
+
+	args, err := applyArgumentFlags(schema, map[string]any{"path": "/a", "count": float64(1)}, []string{"count=5", "extra=x"})
+	require.NoError(t, err)
+	assert.Equal(t, "/a", args["path"])
+	assert.Equal(t, int64(5), args["count"])
+	assert.Equal(t, "x", args["extra"])
+
+	_, err = applyArgumentFlags(schema, map[string]any{}, []string{"path=/a"})
+	assert.ErrorContains(t, err, "missing required arguments: count")
+
+	_, err = applyArgumentFlags(schema, map[string]any{}, []string{"no-equals"})
+	assert.ErrorContains(t, err, "expected key=value")
+}
+
+func TestPromptArguments(t *testing.T) {
+	args, err := promptArguments(map[string]any{"n": float64(3), "s": "text"}, []string{"lang=go"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"n": "3", "s": "text", "lang": "go"}, args)
+}
+
+func TestParsePromptArguments(t *testing.T) {
+	prompt := mcp.NewPrompt("review",
+		mcp.WithArgument("file", mcp.RequiredArgument()),
+		mcp.WithArgument("language"),
+		mcp.WithArgument("focus", mcp.RequiredArgument()),
+	)
+
+	t.Run("positional and key=value", func(t *testing.T) {
+		args, err := parsePromptArguments(prompt, []string{"main.go", "language=go", "security"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"file": "main.go", "language": "go", "focus": "security"}, args)
+	})
+
+	t.Run("key=value for required", func(t *testing.T) {
+		args, err := parsePromptArguments(prompt, []string{"focus=a=b", "main.go"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"file": "main.go", "focus": "a=b"}, args)
+	})
+
+	t.Run("missing required", func(t *testing.T) {
+		_, err := parsePromptArguments(prompt, []string{"language=go"})
+		assert.EqualError(t, err, "missing required arguments: file, focus")
+	})
+
+	t.Run("unknown argument", func(t *testing.T) {
+		_, err := parsePromptArguments(prompt, []string{"main.go", "focus=x", "style=terse"})
+		assert.EqualError(t, err, `unknown argument "style" for prompt review`)
+	})
+
+	t.Run("too many positional", func(t *testing.T) {
+		_, err := parsePromptArguments(prompt, []string{"a", "b", "c"})
+		assert.ErrorContains(t, err, `unexpected positional argument "c"`)
+	})
+}
+
+func TestPromptUsage(t *testing.T) {
+	prompt := mcp.NewPrompt("review",
+		mcp.WithArgument("language"),
+		mcp.WithArgument("file", mcp.RequiredArgument()),
+	)
+	assert.Equal(t, "prompt review <file> [language=<value>]", promptUsage(prompt))
+}
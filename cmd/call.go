@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jbovet/mcp-cli/pkg/output"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/spf13/cobra"
+)
+
+// callCmd represents the call command
+var callCmd = &cobra.Command{
+	Use:   "call <tool-name>",
+	Short: "Call a tool on an MCP server and print the result",
+	Long: `Connect to an MCP server, call a single tool and print its result.
+
+Arguments can be passed as key=value pairs with --arg (converted to the types
+declared in the tool's input schema), as a JSON object with --json, or read
+from a file (or stdin with "-") with --json-file. Values given with --arg take
+precedence over the JSON arguments.
+
+When the server asks for input with an elicitation request, a form is shown
+if the command runs in a terminal. With --elicitation-answers, requests are
+answered from a YAML file instead.
+
+The command exits with a non-zero status when the transport fails or the tool
+reports an error, which makes it suitable for scripts and CI pipelines.`,
+	Example: `  # Call a tool on a stdio server
+  mcp-cli call echo --command "python server.py" --arg message=hello
+
+  # Call a tool on an HTTP server with JSON arguments
+  mcp-cli call search --type http --url "http://localhost:8080/mcp" --json '{"query": "mcp", "limit": 5}'
+
+  # Read the arguments from stdin
+  echo '{"path": "/tmp"}' | mcp-cli call list_files --command "node server.js" --json-file -`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCallCommand,
+}
+
+func runCallCommand(cmd *cobra.Command, args []string) error {
+	toolName := args[0]
+
+	baseArguments, err := readJSONArguments(oneShotJSON, oneShotJSONFile)
+	if err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	// Elicitation requests are answered with a form when the user is at
+	// the terminal
+	if elicitationHandler, err = newElicitationHandler(stdinIsTerminal()); err != nil {
+		return err
+	}
+
+	// Progress is reported on stderr to keep stdout for the result
+	progress := newProgressBar(os.Stderr)
+	notificationHandler = func(notification mcp.JSONRPCNotification) {
+		progress.handle(notification)
+	}
+
+	connectCtx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+	defer cancel()
+
+	serverAdapter, err := connectToServer(connectCtx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := serverAdapter.Disconnect(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to disconnect: %v\n", err)
+		}
+	}()
+
+	// Requests are not bound to the connection timeout
+	ctx := context.Background()
+
+	tools, err := serverAdapter.ListTools(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list tools: %w", err)
+	}
+
+	tool, ok := findTool(tools, toolName)
+	if !ok {
+		return fmt.Errorf("tool '%s' not found", toolName)
+	}
+
+	arguments, err := applyArgumentFlags(tool.InputSchema, baseArguments, oneShotArgs)
+	if err != nil {
+		return fmt.Errorf("%w\nUsage: %s", err, toolUsage(tool))
+	}
+
+	if verbose {
+		fmt.Fprintf(os.Stderr, "Calling tool '%s' with arguments: %+v\n", toolName, arguments)
+	}
+
+	// Ctrl+C cancels the call on the server before exiting
+	callCtx, stop := interruptible(ctx)
+	result, err := serverAdapter.CallTool(progress.start(callCtx), toolName, arguments)
+	stop()
+	progress.done()
+	if err != nil {
+		return err
+	}
+
+	if format := selectedOutputFormat(); format.IsStructured() {
+		err = output.Write(os.Stdout, format, result)
+	} else {
+		err = writeToolResult(os.Stdout, result)
+	}
+	if err != nil {
+		return err
+	}
+
+	if result.IsError {
+		return fmt.Errorf("tool '%s' returned an error", toolName)
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(callCmd)
+
+	addTransportFlags(callCmd)
+	addArgumentFlags(callCmd)
+	addElicitationFlags(callCmd)
+}
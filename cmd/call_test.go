@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOneShotCommands(t *testing.T) {
+	commands := map[string]*cobra.Command{
+		"call <tool-name>": callCmd,
+		"read <resource-uri | template-name> [key=value...]": readCmd,
+		"prompt <prompt-name>":                               promptCmd,
+	}
+
+	for use, command := range commands {
+		t.Run(use, func(t *testing.T) {
+			assert.Equal(t, use, command.Use)
+
+			// Transport flags are shared with the connect command
+			for _, name := range []string{"type", "url", "command", "args", "env", "timeout"} {
+				assert.NotNil(t, command.Flags().Lookup(name), "missing flag --%s", name)
+			}
+
+			found := false
+			for _, cmd := range rootCmd.Commands() {
+				if cmd == command {
+					found = true
+					break
+				}
+			}
+			assert.True(t, found, "command should be registered with root command")
+		})
+	}
+
+	t.Run("argument flags", func(t *testing.T) {
+		for _, command := range []*cobra.Command{callCmd, promptCmd} {
+			assert.NotNil(t, command.Flags().Lookup("arg"))
+			assert.NotNil(t, command.Flags().Lookup("json"))
+			assert.NotNil(t, command.Flags().Lookup("json-file"))
+		}
+		assert.Nil(t, readCmd.Flags().Lookup("arg"))
+	})
+
+	t.Run("requires exactly one argument", func(t *testing.T) {
+		assert.Error(t, callCmd.Args(callCmd, []string{}))
+		assert.NoError(t, callCmd.Args(callCmd, []string{"echo"}))
+	})
+}
+
+// newOneShotServer serves tools, a resource and a prompt over streamable
+// HTTP and points the transport flags at it
+func newOneShotServer(t *testing.T) {
+	mcpServer := server.NewMCPServer("one-shot-test", "1.0.0")
+	mcpServer.AddTool(mcp.NewTool("add",
+		mcp.WithNumber("a", mcp.Required()),
+		mcp.WithNumber("b", mcp.Required()),
+		mcp.WithBoolean("negate"),
+	), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		sum := request.GetFloat("a", 0) + request.GetFloat("b", 0)
+		if request.GetBool("negate", false) {
+			sum = -sum
+		}
+		return mcp.NewToolResultText(fmt.Sprint(sum)), nil
+	})
+	mcpServer.AddTool(mcp.NewTool("slow"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		time.Sleep(500 * time.Millisecond)
+		return mcp.NewToolResultText("done"), nil
+	})
+	mcpServer.AddTool(mcp.NewTool("fail"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultError("something broke"), nil
+	})
+	mcpServer.AddResource(mcp.NewResource("file:///notes.txt", "notes"), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, Text: "remember the milk"}}, nil
+	})
+	mcpServer.AddPrompt(mcp.NewPrompt("greet", mcp.WithArgument("name", mcp.RequiredArgument())), func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return mcp.NewGetPromptResult("Greeting", []mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent("Say hello to "+request.Params.Arguments["name"])),
+		}), nil
+	})
+	testServer := server.NewTestStreamableHTTPServer(mcpServer)
+	t.Cleanup(testServer.Close)
+
+	connectType, connectURL, connectTimeout = "http", testServer.URL+"/mcp", 5*time.Second
+	outputFormat = "table"
+	t.Cleanup(func() {
+		connectType, connectURL, connectTimeout = "stdio", "", 60*time.Second
+		oneShotArgs, oneShotJSON = nil, ""
+		notificationHandler = nil
+	})
+}
+
+// captureStdout returns what run writes to stdout
+func captureStdout(t *testing.T, run func() error) (string, error) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		output <- string(data)
+	}()
+	runErr := run()
+	_ = w.Close()
+	return <-output, runErr
+}
+
+func TestOneShotExecution(t *testing.T) {
+	newOneShotServer(t)
+
+	t.Run("call converts arguments", func(t *testing.T) {
+		oneShotArgs, oneShotJSON = []string{"b=2", "negate=true"}, `{"a": 1}`
+		out, err := captureStdout(t, func() error { return runCallCommand(callCmd, []string{"add"}) })
+		require.NoError(t, err)
+		assert.Contains(t, out, "-3")
+	})
+
+	t.Run("call fails on tool error", func(t *testing.T) {
+		oneShotArgs, oneShotJSON = nil, ""
+		out, err := captureStdout(t, func() error { return runCallCommand(callCmd, []string{"fail"}) })
+		assert.EqualError(t, err, "tool 'fail' returned an error")
+		assert.Contains(t, out, "something broke")
+	})
+
+	t.Run("call outlives the connection timeout", func(t *testing.T) {
+		defer func(timeout time.Duration) { connectTimeout = timeout }(connectTimeout)
+		connectTimeout = 200 * time.Millisecond
+		oneShotArgs, oneShotJSON = nil, ""
+		out, err := captureStdout(t, func() error { return runCallCommand(callCmd, []string{"slow"}) })
+		require.NoError(t, err)
+		assert.Contains(t, out, "done")
+	})
+
+	t.Run("call rejects invalid arguments", func(t *testing.T) {
+		oneShotArgs, oneShotJSON = []string{"a=one", "b=2"}, ""
+		_, err := captureStdout(t, func() error { return runCallCommand(callCmd, []string{"add"}) })
+		assert.ErrorContains(t, err, `"one" is not a valid number`)
+	})
+
+	t.Run("read", func(t *testing.T) {
+		out, err := captureStdout(t, func() error { return runReadCommand(readCmd, []string{"file:///notes.txt"}) })
+		require.NoError(t, err)
+		assert.Equal(t, "remember the milk\n", out)
+	})
+
+	t.Run("prompt", func(t *testing.T) {
+		oneShotArgs, oneShotJSON = []string{"name=Ada"}, ""
+		out, err := captureStdout(t, func() error { return runPromptCommand(promptCmd, []string{"greet"}) })
+		require.NoError(t, err)
+		assert.Contains(t, out, "Say hello to Ada")
+	})
+}
@@ -0,0 +1,326 @@
+package cmd
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// completionTimeout bounds the requests made to complete a line, so a slow
+// server does not block the prompt
+const completionTimeout = 5 * time.Second
+
+// replCommands are the commands of interactive mode
+var replCommands = []string{
+	"call", "exit", "help", "loglevel", "prompt", "prompts", "quit", "read",
+	"resources", "roots", "stderr", "templates", "tools", "watch",
+}
+
+// completion is the result of completing a line: the line with the word
+// before the cursor completed as far as possible, and the candidates for
+// that word
+type completion struct {
+	line       string
+	candidates []string
+}
+
+// replCompleter completes the command lines of interactive mode. Command,
+// tool, prompt and template names and argument names are completed from the
+// listings of the server, and the values of prompt arguments and template
+// variables with completion/complete.
+type replCompleter struct {
+	ctx      context.Context
+	adapter  adapter.ServerAdapter
+	listings *listingCache
+}
+
+// completionListings are the listings completing the lines of interactive
+// mode, dropped on the list_changed notifications of the server
+var completionListings = &listingCache{}
+
+// listingCache keeps the listings of the server for the session, so they are
+// not requested again on each Tab. A listing is dropped when the server
+// notifies that it changed.
+type listingCache struct {
+	tools     cachedListing[mcp.Tool]
+	prompts   cachedListing[mcp.Prompt]
+	resources cachedListing[mcp.Resource]
+	templates cachedListing[mcp.ResourceTemplate]
+}
+
+// invalidate drops the listings a list_changed notification is about
+func (c *listingCache) invalidate(notification mcp.JSONRPCNotification) {
+	switch notification.Method {
+	case mcp.MethodNotificationToolsListChanged:
+		c.tools.drop()
+	case mcp.MethodNotificationPromptsListChanged:
+		c.prompts.drop()
+	case mcp.MethodNotificationResourcesListChanged:
+		c.resources.drop()
+		c.templates.drop()
+	}
+}
+
+// cachedListing is a listing of the server kept until it is dropped
+type cachedListing[T any] struct {
+	mu    sync.Mutex
+	items []T
+	valid bool
+	// generation is incremented when the listing is dropped, so a listing
+	// requested meanwhile is not kept
+	generation int
+}
+
+// get returns the kept listing, or requests it with list. Failed listings
+// are not kept.
+func (l *cachedListing[T]) get(ctx context.Context, list func(context.Context) ([]T, error)) ([]T, error) {
+	l.mu.Lock()
+	if l.valid {
+		defer l.mu.Unlock()
+		return l.items, nil
+	}
+	generation := l.generation
+	l.mu.Unlock()
+
+	items, err := list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.generation == generation {
+		l.items, l.valid = items, true
+	}
+	return items, nil
+}
+
+func (l *cachedListing[T]) drop() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.items, l.valid = nil, false
+	l.generation++
+}
+
+// complete completes the last word of line, which is the text before the
+// cursor
+func (c *replCompleter) complete(line string) completion {
+	words := strings.Fields(line)
+	if len(words) == 0 || !strings.HasSuffix(line, " ") && len(words) == 1 {
+		return completeWord(line, wordPrefix(words), replCommands, " ")
+	}
+
+	current := ""
+	if !strings.HasSuffix(line, " ") {
+		current = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, completionTimeout)
+	defer cancel()
+
+	command, args := words[0], words[1:]
+	if len(args) == 0 {
+		return completeWord(line, current, c.names(ctx, command), " ")
+	}
+	if name, value, found := strings.Cut(current, "="); found {
+		return replaceWord(line, current, c.values(ctx, command, args[0], name, value, args[1:]), " ")
+	}
+	return completeWord(line, current, c.keys(ctx, command, args[0]), "")
+}
+
+// wordPrefix returns the word being completed when the line holds a single
+// word
+func wordPrefix(words []string) string {
+	if len(words) == 0 {
+		return ""
+	}
+	return words[0]
+}
+
+// names returns the candidates for the first argument of a command
+func (c *replCompleter) names(ctx context.Context, command string) []string {
+	var names []string
+	switch command {
+	case "call":
+		if tools, err := c.listings.tools.get(ctx, c.adapter.ListTools); err == nil {
+			for _, tool := range tools {
+				names = append(names, tool.Name)
+			}
+		}
+	case "prompt":
+		if prompts, err := c.listings.prompts.get(ctx, c.adapter.ListPrompts); err == nil {
+			for _, prompt := range prompts {
+				names = append(names, prompt.Name)
+			}
+		}
+	case "read", "watch":
+		if templates, err := c.listings.templates.get(ctx, c.adapter.ListResourceTemplates); err == nil {
+			for _, template := range templates {
+				names = append(names, template.Name)
+			}
+		}
+		if resources, err := c.listings.resources.get(ctx, c.adapter.ListResources); err == nil {
+			for _, resource := range resources {
+				names = append(names, resource.URI)
+			}
+		}
+	case "loglevel":
+		for _, level := range adapter.LoggingLevels() {
+			names = append(names, string(level))
+		}
+	case "roots":
+		names = []string{"add", "remove"}
+	}
+	return names
+}
+
+// keys returns the key= candidates for the arguments of a tool or a prompt,
+// or the variables of a resource template
+func (c *replCompleter) keys(ctx context.Context, command, name string) []string {
+	var keys []string
+	switch command {
+	case "call":
+		if tools, err := c.listings.tools.get(ctx, c.adapter.ListTools); err == nil {
+			if tool, ok := findTool(tools, name); ok {
+				for key := range tool.InputSchema.Properties {
+					keys = append(keys, key+"=")
+				}
+			}
+		}
+	case "prompt":
+		if prompts, err := c.listings.prompts.get(ctx, c.adapter.ListPrompts); err == nil {
+			if prompt, ok := findPrompt(prompts, name); ok {
+				for _, argument := range prompt.Arguments {
+					keys = append(keys, argument.Name+"=")
+				}
+			}
+		}
+	case "read", "watch":
+		if template, ok := c.template(ctx, name); ok {
+			for _, variable := range template.URITemplate.Varnames() {
+				keys = append(keys, variable+"=")
+			}
+		}
+	}
+	return keys
+}
+
+// values asks the server for the values of a prompt argument or a template
+// variable, given the values of the other arguments on the line. The
+// candidates are returned as key=value words.
+func (c *replCompleter) values(ctx context.Context, command, name, key, value string, pairs []string) []string {
+	var ref any
+	switch command {
+	case "prompt":
+		ref = adapter.PromptReference(name)
+	case "read", "watch":
+		template, ok := c.template(ctx, name)
+		if !ok {
+			return nil
+		}
+		ref = adapter.ResourceReference(templateURI(template))
+	default:
+		return nil
+	}
+
+	arguments := make(map[string]string)
+	for _, pair := range pairs {
+		if k, v, found := strings.Cut(pair, "="); found && k != key {
+			arguments[k] = strings.Trim(v, `"'`)
+		}
+	}
+	argument := mcp.CompleteArgument{Name: key, Value: strings.TrimLeft(value, `"'`)}
+	result, err := c.adapter.Complete(ctx, ref, argument, arguments)
+	if err != nil {
+		return nil
+	}
+
+	candidates := make([]string, len(result.Values))
+	for i, v := range result.Values {
+		candidates[i] = key + "=" + quoteWord(v)
+	}
+	return candidates
+}
+
+// template looks up a resource template with a URI template
+func (c *replCompleter) template(ctx context.Context, name string) (mcp.ResourceTemplate, bool) {
+	templates, err := c.listings.templates.get(ctx, c.adapter.ListResourceTemplates)
+	if err != nil {
+		return mcp.ResourceTemplate{}, false
+	}
+	template, ok := findResourceTemplate(templates, name)
+	return template, ok && templateURI(template) != ""
+}
+
+// completeWord completes the word at the end of line with the candidates it
+// prefixes
+func completeWord(line, word string, candidates []string, suffix string) completion {
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, word) {
+			matches = append(matches, candidate)
+		}
+	}
+	return replaceWord(line, word, matches, suffix)
+}
+
+// replaceWord replaces the word at the end of line with a single candidate
+// followed by suffix, or with the common prefix of several candidates when
+// it is longer than the word. Candidates are not required to start with the
+// word, as servers may match values by other means than prefixes.
+func replaceWord(line, word string, candidates []string, suffix string) completion {
+	var matches []string
+	for _, candidate := range candidates {
+		if !slices.Contains(matches, candidate) {
+			matches = append(matches, candidate)
+		}
+	}
+	sort.Strings(matches)
+
+	base := strings.TrimSuffix(line, word)
+	switch len(matches) {
+	case 0:
+		return completion{line: line}
+	case 1:
+		if strings.HasSuffix(matches[0], "=") {
+			suffix = ""
+		}
+		return completion{line: base + matches[0] + suffix, candidates: matches}
+	}
+	if prefix := commonPrefix(matches); len(prefix) > len(word) {
+		return completion{line: base + prefix, candidates: matches}
+	}
+	return completion{line: line, candidates: matches}
+}
+
+// commonPrefix returns the longest prefix shared by words
+func commonPrefix(words []string) string {
+	prefix := words[0]
+	for _, word := range words[1:] {
+		for !strings.HasPrefix(word, prefix) {
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+	return prefix
+}
+
+// quoteWord quotes a value for splitCommandLine when it holds spaces, quotes
+// or characters starting JSON
+func quoteWord(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\"'\\{[") {
+		return s
+	}
+	if !strings.Contains(s, "'") {
+		return "'" + s + "'"
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// replCompletionProvider completes prompt arguments from a fixed list, and
+// template variables from the other variables
+type replCompletionProvider struct{}
+
+func (replCompletionProvider) CompletePromptArgument(ctx context.Context, promptName string, argument mcp.CompleteArgument, context mcp.CompleteContext) (*mcp.Completion, error) {
+	var values []string
+	for _, value := range []string{"go", "golang", "python", "hello world"} {
+		if strings.HasPrefix(value, argument.Value) {
+			values = append(values, value)
+		}
+	}
+	return &mcp.Completion{Values: values}, nil
+}
+
+func (replCompletionProvider) CompleteResourceArgument(ctx context.Context, uri string, argument mcp.CompleteArgument, context mcp.CompleteContext) (*mcp.Completion, error) {
+	return &mcp.Completion{Values: []string{context.Arguments["owner"] + "-" + argument.Value + "x"}}, nil
+}
+
+func TestReplCompleter(t *testing.T) {
+	mcpServer := server.NewMCPServer("completion-test", "1.0.0",
+		server.WithCompletions(),
+		server.WithPromptCompletionProvider(replCompletionProvider{}),
+		server.WithResourceCompletionProvider(replCompletionProvider{}),
+	)
+	mcpServer.AddTool(mcp.NewTool("search", mcp.WithString("query"), mcp.WithNumber("limit")), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(""), nil
+	})
+	mcpServer.AddTool(mcp.NewTool("send"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(""), nil
+	})
+	mcpServer.AddPrompt(mcp.NewPrompt("review", mcp.WithArgument("language"), mcp.WithArgument("level")), func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return mcp.NewGetPromptResult("", nil), nil
+	})
+	mcpServer.AddResourceTemplate(mcp.NewResourceTemplate("repo://{owner}/{repo}", "repository"), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return nil, nil
+	})
+	testServer := server.NewTestStreamableHTTPServer(mcpServer)
+	defer testServer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	serverAdapter, err := adapter.NewAdapter(adapter.AdapterTypeAuto, adapter.Config{ServerURL: testServer.URL + "/mcp", Timeout: 5 * time.Second})
+	require.NoError(t, err)
+	require.NoError(t, serverAdapter.Connect(ctx))
+	defer func() { _ = serverAdapter.Disconnect() }()
+
+	completer := &replCompleter{ctx: ctx, adapter: serverAdapter, listings: &listingCache{}}
+
+	tests := []struct {
+		name       string
+		line       string
+		want       string
+		candidates []string
+	}{
+		{name: "commands", line: "", want: "", candidates: replCommands},
+		{name: "command", line: "ca", want: "call ", candidates: []string{"call"}},
+		{name: "command prefix", line: "pro", want: "prompt", candidates: []string{"prompt", "prompts"}},
+		{name: "tool", line: "call se", want: "call se", candidates: []string{"search", "send"}},
+		{name: "tool argument", line: "call search q", want: "call search query=", candidates: []string{"query="}},
+		{name: "prompt", line: "prompt r", want: "prompt review ", candidates: []string{"review"}},
+		{name: "prompt argument", line: "prompt review l", want: "prompt review l", candidates: []string{"language=", "level="}},
+		{name: "prompt value", line: "prompt review language=py", want: "prompt review language=python ", candidates: []string{"language=python"}},
+		{name: "prompt values", line: "prompt review language=g", want: "prompt review language=go", candidates: []string{"language=go", "language=golang"}},
+		{name: "quoted value", line: "prompt review language=h", want: "prompt review language='hello world' ", candidates: []string{"language='hello world'"}},
+		{name: "template", line: "read rep", want: "read repository ", candidates: []string{"repository"}},
+		{name: "template variable", line: "watch repository owner=jbovet r", want: "watch repository owner=jbovet repo=", candidates: []string{"repo="}},
+		{name: "template value", line: "read repository owner=jbovet repo=mcp", want: "read repository owner=jbovet repo=jbovet-mcpx ", candidates: []string{"repo=jbovet-mcpx"}},
+		{name: "log level", line: "loglevel w", want: "loglevel warning ", candidates: []string{"warning"}},
+		{name: "roots", line: "roots ", want: "roots ", candidates: []string{"add", "remove"}},
+		{name: "no candidates", line: "call unknown x", want: "call unknown x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := completer.complete(tt.line)
+			assert.Equal(t, tt.want, result.line)
+			assert.Equal(t, tt.candidates, result.candidates)
+		})
+	}
+
+	t.Run("listings kept until changed", func(t *testing.T) {
+		mcpServer.AddTool(mcp.NewTool("select"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(""), nil
+		})
+		assert.Equal(t, []string{"search", "send"}, completer.complete("call se").candidates)
+
+		// Other listings are kept
+		completer.listings.invalidate(mcp.JSONRPCNotification{Notification: mcp.Notification{Method: mcp.MethodNotificationPromptsListChanged}})
+		assert.Equal(t, []string{"search", "send"}, completer.complete("call se").candidates)
+
+		completer.listings.invalidate(mcp.JSONRPCNotification{Notification: mcp.Notification{Method: mcp.MethodNotificationToolsListChanged}})
+		assert.Equal(t, []string{"search", "select", "send"}, completer.complete("call se").candidates)
+	})
+}
+
+func TestQuoteWord(t *testing.T) {
+	assert.Equal(t, "plain", quoteWord("plain"))
+	assert.Equal(t, "''", quoteWord(""))
+	assert.Equal(t, "'two words'", quoteWord("two words"))
+	assert.Equal(t, `"it's \"here\""`, quoteWord(`it's "here"`))
+
+	parts, err := splitCommandLine("prompt x a=" + quoteWord(`it's "here"`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prompt", "x", `a=it's "here"`}, parts)
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jbovet/mcp-cli/pkg/config"
+	"github.com/jbovet/mcp-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Flags for config import command
+	importFrom   string
+	importForce  bool
+	importDryRun bool
+)
+
+// configCmd represents the config command group
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the mcp-cli server configuration file",
+	Long: `Manage the server configuration file used by "mcp-cli connect <name>"
+(~/.config/mcp-cli/servers.yaml by default, see --config).`,
+}
+
+// configImportCmd represents the config import command
+var configImportCmd = &cobra.Command{
+	Use:   "import [path]",
+	Short: "Import MCP servers from Claude Desktop, VS Code or Cursor",
+	Long: `Import the MCP servers defined for another application into the mcp-cli
+configuration file.
+
+Supported sources and their default locations:
+- claude-desktop: claude_desktop_config.json in the Claude application directory
+- vscode:         .vscode/mcp.json in the current directory
+- cursor:         .cursor/mcp.json in the current directory, or ~/.cursor/mcp.json
+
+The type, command, args, env, url and headers of each server are imported.
+Fields mcp-cli does not support and variables only the source application can
+resolve (such as ${input:...}) are reported. Comments and trailing commas,
+which VS Code and Cursor allow, are accepted. VS Code style ${env:NAME}
+references in headers, args and env values are converted to ${NAME}.
+
+Servers whose name already exists with a different definition are reported as
+conflicts and skipped, unless --force is given. The other entries of the
+configuration file are kept with their comments and formatting.`,
+	Example: `  # Import the servers configured in Claude Desktop
+  mcp-cli config import --from claude-desktop
+
+  # Import the servers of a VS Code workspace
+  mcp-cli config import --from vscode ~/projects/app/.vscode/mcp.json
+
+  # Preview the import without writing the configuration file
+  mcp-cli config import --from cursor --dry-run`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigImport,
+}
+
+// importResult is the outcome of importing one server
+type importResult struct {
+	Name     string   `json:"name"`
+	Status   string   `json:"status"`
+	Type     string   `json:"type,omitempty"`
+	Message  string   `json:"message,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+const (
+	importStatusImported  = "imported"
+	importStatusUpdated   = "updated"
+	importStatusUnchanged = "unchanged"
+	importStatusConflict  = "conflict"
+	importStatusInvalid   = "invalid"
+)
+
+func runConfigImport(cmd *cobra.Command, args []string) error {
+	source, err := config.ParseSource(importFrom)
+	if err != nil {
+		return err
+	}
+
+	path := ""
+	if len(args) > 0 {
+		path = args[0]
+	} else if path, err = config.DefaultImportPath(source); err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s configuration: %w", source, err)
+	}
+	imported, err := config.Import(source, data)
+	if err != nil {
+		return err
+	}
+
+	file, err := config.Load(configPath)
+	if err != nil {
+		return err
+	}
+
+	results := mergeImportedServers(file, imported, importForce)
+
+	changed := false
+	for _, result := range results {
+		if result.Status == importStatusImported || result.Status == importStatusUpdated {
+			changed = true
+		}
+	}
+	if changed && !importDryRun {
+		if err := file.Save(); err != nil {
+			return err
+		}
+	}
+
+	if format := selectedOutputFormat(); format.IsStructured() {
+		return output.Write(os.Stdout, format, results)
+	}
+
+	if len(results) == 0 {
+		fmt.Printf("No servers found in %s\n", path)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tSTATUS\tTYPE\tDETAILS")
+	_, _ = fmt.Fprintln(w, "----\t------\t----\t-------")
+	for _, result := range results {
+		details := result.Message
+		for _, warning := range result.Warnings {
+			if details != "" {
+				details += "; "
+			}
+			details += "warning: " + warning
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", result.Name, result.Status, result.Type, details)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	switch {
+	case importDryRun:
+		fmt.Printf("\nDry run: %s was not modified\n", file.Path())
+	case changed:
+		fmt.Printf("\nSaved to %s\n", file.Path())
+	}
+	return nil
+}
+
+// mergeImportedServers adds the imported servers to the configuration file.
+// Existing entries with the same name are only replaced when force is set.
+func mergeImportedServers(file *config.File, imported []config.ImportedServer, force bool) []importResult {
+	results := make([]importResult, 0, len(imported))
+	for _, server := range imported {
+		result := importResult{Name: server.Name, Warnings: server.Warnings}
+		if server.Err != nil {
+			result.Status = importStatusInvalid
+			result.Message = server.Err.Error()
+			results = append(results, result)
+			continue
+		}
+		result.Type = server.Server.TransportType()
+
+		existing, exists := file.Servers[server.Name]
+		switch {
+		case !exists:
+			result.Status = importStatusImported
+		case reflect.DeepEqual(existing, server.Server):
+			result.Status = importStatusUnchanged
+		case force:
+			result.Status = importStatusUpdated
+		default:
+			result.Status = importStatusConflict
+			result.Message = fmt.Sprintf("a different server %q is already configured (use --force to overwrite)", server.Name)
+		}
+
+		if result.Status == importStatusImported || result.Status == importStatusUpdated {
+			file.Servers[server.Name] = server.Server
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configImportCmd)
+
+	sources := make([]string, 0, len(config.Sources()))
+	for _, source := range config.Sources() {
+		sources = append(sources, string(source))
+	}
+	configImportCmd.Flags().StringVar(&importFrom, "from", "", "Application to import from ("+strings.Join(sources, ", ")+")")
+	configImportCmd.Flags().BoolVar(&importForce, "force", false, "Overwrite configured servers with the same name")
+	configImportCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Show what would be imported without writing the configuration file")
+	_ = configImportCmd.MarkFlagRequired("from")
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jbovet/mcp-cli/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeImportedServers(t *testing.T) {
+	file, err := config.Load(filepath.Join(t.TempDir(), "servers.yaml"))
+	require.NoError(t, err)
+	file.Servers["same"] = config.Server{Command: "same"}
+	file.Servers["other"] = config.Server{Command: "old"}
+
+	imported := []config.ImportedServer{
+		{Name: "new", Server: config.Server{URL: "https://example.com/mcp"}, Warnings: []string{"w"}},
+		{Name: "same", Server: config.Server{Command: "same"}},
+		{Name: "other", Server: config.Server{Command: "new"}},
+		{Name: "bad", Err: assert.AnError},
+	}
+
+	results := mergeImportedServers(file, imported, false)
+	require.Len(t, results, 4)
+	assert.Equal(t, importResult{Name: "new", Status: "imported", Type: "auto", Warnings: []string{"w"}}, results[0])
+	assert.Equal(t, "unchanged", results[1].Status)
+	assert.Equal(t, "conflict", results[2].Status)
+	assert.Contains(t, results[2].Message, "use --force to overwrite")
+	assert.Equal(t, "invalid", results[3].Status)
+	assert.Equal(t, "old", file.Servers["other"].Command)
+	assert.Contains(t, file.Servers, "new")
+
+	results = mergeImportedServers(file, imported[2:3], true)
+	assert.Equal(t, "updated", results[0].Status)
+	assert.Equal(t, "new", file.Servers["other"].Command)
+}
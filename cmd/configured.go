@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jbovet/mcp-cli/pkg/config"
+	"github.com/jbovet/mcp-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// configuredServersCmd represents the servers command group for the
+// servers defined in the configuration file
+var configuredServersCmd = &cobra.Command{
+	Use:   "servers",
+	Short: "Manage the MCP servers defined in the configuration file",
+	Long: `Manage the named MCP servers defined in the configuration file
+(~/.config/mcp-cli/servers.yaml by default, see --config).
+
+Each entry defines how to reach a server, so it can be used with
+"mcp-cli connect <name>" or the --server flag of call, read and prompt:
+
+  servers:
+    filesystem:
+      type: stdio
+      command: npx
+      args: ["-y", "@modelcontextprotocol/server-filesystem", "/tmp"]
+      env:
+        DEBUG: "1"
+    remote:
+      type: http
+      url: https://api.example.com/mcp
+      headers:
+        Authorization: Bearer ${API_TOKEN}
+      timeout: 30s
+
+When type is omitted, entries with a url detect the transport (auto) and
+others use stdio.`,
+}
+
+// configuredServersListCmd represents the servers list command
+var configuredServersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the servers defined in the configuration file",
+	Example: `  # List the configured servers
+  mcp-cli servers list
+
+  # Include environment variable and header names
+  mcp-cli servers list --output wide
+
+  # Structured output, with the names of environment variables and headers
+  # but not their values
+  mcp-cli servers list --output json
+
+  # Use another configuration file
+  mcp-cli servers list --config ./servers.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runConfiguredServersList,
+}
+
+// configuredServer is the structured output of a configured server. Like the
+// table, it only holds the names of the environment variables and headers.
+type configuredServer struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type,omitempty"`
+	Command string   `json:"command,omitempty"`
+	Args    []string `json:"args,omitempty"`
+	Env     []string `json:"env,omitempty"`
+	URL     string   `json:"url,omitempty"`
+	Headers []string `json:"headers,omitempty"`
+	Timeout string   `json:"timeout,omitempty"`
+}
+
+func newConfiguredServer(name string, server config.Server) configuredServer {
+	return configuredServer{
+		Name:    name,
+		Type:    server.Type,
+		Command: server.Command,
+		Args:    server.Args,
+		Env:     sortedKeys(server.Env),
+		URL:     server.URL,
+		Headers: sortedKeys(server.Headers),
+		Timeout: server.Timeout,
+	}
+}
+
+func runConfiguredServersList(cmd *cobra.Command, args []string) error {
+	file, err := config.Load(configPath)
+	if err != nil {
+		return err
+	}
+
+	if format := selectedOutputFormat(); format.IsStructured() {
+		servers := make([]configuredServer, 0, len(file.Servers))
+		for _, name := range file.Names() {
+			servers = append(servers, newConfiguredServer(name, file.Servers[name]))
+		}
+		return output.Write(os.Stdout, format, servers)
+	}
+
+	if len(file.Servers) == 0 {
+		fmt.Printf("No servers configured in %s\n", file.Path())
+		return nil
+	}
+
+	wide := selectedOutputFormat() == output.FormatWide
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if wide {
+		_, _ = fmt.Fprintln(w, "NAME\tTYPE\tTARGET\tTIMEOUT\tENV\tHEADERS")
+		_, _ = fmt.Fprintln(w, "----\t----\t------\t-------\t---\t-------")
+	} else {
+		_, _ = fmt.Fprintln(w, "NAME\tTYPE\tTARGET")
+		_, _ = fmt.Fprintln(w, "----\t----\t------")
+	}
+
+	for _, name := range file.Names() {
+		server := file.Servers[name]
+		if wide {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				name,
+				server.TransportType(),
+				server.Target(),
+				server.Timeout,
+				strings.Join(sortedKeys(server.Env), ","),
+				strings.Join(sortedKeys(server.Headers), ","),
+			)
+			continue
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", name, server.TransportType(), truncateText(server.Target(), 60))
+	}
+
+	return w.Flush()
+}
+
+// sortedKeys returns the keys of a map in sorted order. Only names are
+// shown since values may hold credentials.
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func init() {
+	rootCmd.AddCommand(configuredServersCmd)
+	configuredServersCmd.AddCommand(configuredServersListCmd)
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAdapterFromConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "servers.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`servers:
+  local:
+    command: python server.py
+  remote:
+    url: https://api.example.com/mcp
+`), 0o600))
+
+	defer func(path, name string) { configPath, serverName = path, name }(configPath, serverName)
+	configPath = path
+
+	serverName = "local"
+	serverAdapter, adapterType, err := createAdapter()
+	require.NoError(t, err)
+	assert.IsType(t, &adapter.StdioAdapter{}, serverAdapter)
+	assert.Equal(t, adapter.AdapterTypeStdio, adapterType)
+
+	serverName = "remote"
+	serverAdapter, adapterType, err = createAdapter()
+	require.NoError(t, err)
+	assert.IsType(t, &adapter.AutoAdapter{}, serverAdapter)
+	assert.Equal(t, adapter.AdapterTypeAuto, adapterType)
+
+	serverName = "unknown"
+	_, _, err = createAdapter()
+	assert.ErrorContains(t, err, `server "unknown" not found`)
+}
+
+func TestConfiguredServersCommand(t *testing.T) {
+	assert.Equal(t, "servers", configuredServersCmd.Use)
+	assert.Contains(t, configuredServersCmd.Commands(), configuredServersListCmd)
+	assert.Contains(t, rootCmd.Commands(), configuredServersCmd)
+	assert.NotNil(t, rootCmd.PersistentFlags().Lookup("config"))
+	assert.NotNil(t, connectCmd.Flags().Lookup("server"))
+	assert.NotNil(t, callCmd.Flags().Lookup("server"))
+}
+
+func TestConfiguredServersListOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "servers.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`servers:
+  local:
+    command: python server.py
+    env:
+      API_KEY: sk-local-secret
+  remote:
+    url: https://api.example.com/mcp
+    headers:
+      Authorization: Bearer remote-secret
+`), 0o600))
+
+	defer func(path, format string) { configPath, outputFormat = path, format }(configPath, outputFormat)
+	configPath, outputFormat = path, "json"
+
+	out, err := captureStdout(t, func() error { return runConfiguredServersList(configuredServersListCmd, nil) })
+	require.NoError(t, err)
+	assert.NotContains(t, out, "secret")
+
+	var servers []configuredServer
+	require.NoError(t, json.Unmarshal([]byte(out), &servers))
+	assert.Equal(t, []configuredServer{
+		{Name: "local", Command: "python server.py", Env: []string{"API_KEY"}},
+		{Name: "remote", URL: "https://api.example.com/mcp", Headers: []string{"Authorization"}},
+	}, servers)
+}
@@ -1,33 +1,48 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"text/tabwriter"
 	"time"
 
 	"github.com/jbovet/mcp-cli/pkg/adapter"
+	"github.com/jbovet/mcp-cli/pkg/config"
+	"github.com/jbovet/mcp-cli/pkg/output"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/spf13/cobra"
 )
 
 var (
 	// Flags for connect command
+	serverName      string
 	connectType     string
 	connectURL      string
 	connectCommand  string
 	connectArgs     []string
 	connectEnv      []string
+	connectHeaders  []string
+	bearerToken     string
 	connectTimeout  time.Duration
 	interactiveMode bool
+
+	// Flags selecting a server from the MCP Registry
+	registryServer  string
+	registryURL     string
+	registryRemote  int
+	registryPackage int
+
+	// connectedTransport is the transport selected by connectToServer
+	connectedTransport adapter.AdapterType
 )
 
 // connectCmd represents the connect command
 var connectCmd = &cobra.Command{
-	Use:   "connect",
+	Use:   "connect [server-name]",
 	Short: "Connect to an MCP server using different transport methods",
 	Long: `Connect to an MCP (Model Context Protocol) server using various transport methods.
 
@@ -35,35 +50,235 @@ Supports:
 - stdio: Connect to a local server process via standard input/output
 - http: Connect to an HTTP-based MCP server
 - streamable: Connect to a streamable HTTP-based MCP server
+- sse: Connect to an MCP server using the legacy HTTP+SSE transport
+- auto: Detect whether an HTTP server uses the streamable HTTP or the legacy
+  SSE transport (tries streamable HTTP first and falls back to SSE on 4xx)
+
+Instead of transport flags, the name of a server defined in the configuration
+file (see "mcp-cli servers list") can be given.
+
+With --registry, the latest version of a server listed in the MCP Registry is
+looked up. One of its remotes is connected to or, when it has none (or with
+--package), one of its packages is run with the runtime of its registry (see
+"mcp-cli install"). The inputs the remote headers or the package declare can
+be given with --input name=value, the others are prompted for.
+
+HTTP servers requiring OAuth authorization are supported: mcp-cli discovers
+the authorization server, registers itself when no client ID is given and opens
+the authorization page in a browser. Tokens are cached per server URL in the
+user cache directory and refreshed when they expire.
 
 The command can run in interactive mode to explore the server's capabilities
-or execute specific operations.`,
-	Example: `  # Connect to a stdio server
+or execute specific operations. Notifications sent by the server (log
+messages, list changes, resource updates) are printed as they arrive in
+interactive mode, and with --follow, which keeps the connection open until
+interrupted. With --output json, --follow prints one notification per line.
+
+With --sampling, the client offers sampling to the server and answers its
+sampling/createMessage requests: "--sampling interactive" shows each request
+and lets you type and approve the response, while "--sampling <file>" answers
+with the first canned response of a YAML file matching the request messages.
+
+In interactive mode, the elicitation requests through which a server asks for
+input are answered with a form generated from the requested schema. With
+--elicitation-answers, they are answered from a YAML file instead.
+
+The working directory is offered to the server as its filesystem root, unless
+other roots are given with --root. In interactive mode, "roots add" and
+"roots remove" change them and notify the server.
+
+With --script, the interactive mode commands of a file are run one after the
+other and echoed, for example to replay the steps of a guide or a smoke test.
+Blank lines and lines starting with # are skipped. The first failed command
+stops the script with an error, unless --continue-on-error is given, in which
+case the script still fails once all commands have run. Interactive mode runs
+its standard input as a script when it is not a terminal.`,
+	Example: `  # Connect to a server defined in the configuration file
+  mcp-cli connect filesystem
+
+  # Connect to a server listed in the MCP Registry
+  mcp-cli connect --registry io.github.owner/server-name --registry-url https://registry.example.com
+
+  # Run the second package of a registry server instead of using a remote
+  mcp-cli connect --registry io.github.owner/server-name --package 2
+
+  # Connect to a stdio server
   mcp-cli connect --type stdio --command "python" --args "server.py"
 
   # Connect to an HTTP server
   mcp-cli connect --type http --url "http://localhost:8080/mcp"
 
+  # Connect to a server using the legacy SSE transport
+  mcp-cli connect --type sse --url "http://localhost:8080/sse"
+
+  # Detect the transport of an HTTP server
+  mcp-cli connect --type auto --url "http://localhost:8080/mcp"
+
+  # Connect to a server requiring OAuth, printing the authorization URL
+  mcp-cli connect --type http --url "https://api.example.com/mcp" --no-browser
+
+  # Connect to an HTTP server requiring an API key read from the environment
+  mcp-cli connect --type http --url "https://api.example.com/mcp" --header 'X-API-Key: ${API_KEY}'
+
   # Connect with custom environment variables
   mcp-cli connect --type stdio --command "node" --args "server.js" --env "DEBUG=1"
 
+  # Append the stderr output of a stdio server to a file
+  mcp-cli connect --command "python server.py" --interactive --stderr-log server.log
+
+  # Stream the log messages and other notifications of a server
+  mcp-cli connect filesystem --follow --log-level debug
+
   # Connect in interactive mode
-  mcp-cli connect --type stdio --command "python server.py" --interactive`,
+  mcp-cli connect --type stdio --command "python server.py" --interactive
+
+  # Replay the commands of a file, stopping at the first failure
+  mcp-cli connect filesystem --script smoke.mcp
+
+  # Pipe commands to interactive mode
+  echo "call echo message=hi" | mcp-cli connect filesystem --interactive
+
+  # Let a filesystem server access two project directories
+  mcp-cli connect filesystem --root ~/src/app --root ~/src/lib --interactive
+
+  # Answer the sampling requests of a server yourself
+  mcp-cli connect --command "python server.py" --interactive --sampling interactive`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runConnectCommand,
 }
 
 func runConnectCommand(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		if serverName != "" && serverName != args[0] {
+			return fmt.Errorf("server name given both as argument and with --server")
+		}
+		serverName = args[0]
+	}
+
+	var logLevel mcp.LoggingLevel
+	if connectLogLevel != "" {
+		level, err := adapter.ParseLoggingLevel(connectLogLevel)
+		if err != nil {
+			return err
+		}
+		logLevel = level
+	}
+
+	if connectSampling != "" {
+		// Scripts and piped commands would be read as responses
+		handler, err := newSamplingHandler(connectSampling, !scriptMode() && stdinIsTerminal())
+		if err != nil {
+			return err
+		}
+		samplingHandler = handler
+	}
+	// Forms are not shown when the commands are read from stdin
+	handler, err := newElicitationHandler((interactiveMode || connectScript != "") && stdinIsTerminal() && connectScript != "-")
+	if err != nil {
+		return err
+	}
+	elicitationHandler = handler
+
+	// Notifications are printed as they arrive when following them, in
+	// interactive mode and while running a script
+	switch {
+	case interactiveMode || connectScript != "":
+		printer := newNotificationPrinter(replOutput, output.FormatTable)
+		notificationHandler = func(notification mcp.JSONRPCNotification) {
+			completionListings.invalidate(notification)
+			// Updates of watched resources are shown by the watch command
+			// and tool progress by a progress bar
+			if watches.handle(notification) || toolProgress.handle(notification) {
+				return
+			}
+			toolProgress.done()
+			printer.handle(notification)
+		}
+	case followMode:
+		notificationHandler = newNotificationPrinter(os.Stdout, selectedOutputFormat()).handle
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
 	defer cancel()
 
-	// Create adapter configuration
+	// Connect to the server
+	serverAdapter, err := connectToServer(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := serverAdapter.Disconnect(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to disconnect: %v\n", err)
+		}
+	}()
+
+	// Get server information
+	serverInfo, err := serverAdapter.GetServerInfo()
+	if err != nil {
+		return fmt.Errorf("failed to get server info: %w", err)
+	}
+
+	if !selectedOutputFormat().IsStructured() || interactiveMode || connectScript != "" {
+		if connectedTransport == adapter.AdapterTypeAuto {
+			fmt.Printf("✓ Connected to MCP server: %s (version %s) using %s transport\n\n",
+				serverInfo.Name, serverInfo.Version, transportName(serverAdapter))
+		} else {
+			fmt.Printf("✓ Connected to MCP server: %s (version %s)\n\n",
+				serverInfo.Name, serverInfo.Version)
+		}
+	}
+
+	if logLevel != "" {
+		setLogLevel(ctx, serverAdapter, logLevel)
+	}
+
+	// The session is not bound to the connection timeout
+	if scriptMode() {
+		// Failed commands are reported without the usage of connect
+		cmd.SilenceUsage = true
+		return runScriptMode(context.Background(), serverAdapter)
+	}
+	if interactiveMode {
+		return runInteractiveMode(context.Background(), serverAdapter)
+	}
+
+	if followMode {
+		// Structured output only holds the notifications
+		if !selectedOutputFormat().IsStructured() {
+			if err := showServerCapabilities(ctx, serverAdapter); err != nil {
+				return err
+			}
+			fmt.Println()
+		}
+		// A lost connection is reported without the usage of connect
+		cmd.SilenceUsage = true
+		return followNotifications(serverAdapter)
+	}
+
+	// Default: show server capabilities
+	return showServerCapabilities(ctx, serverAdapter)
+}
+
+// connectionConfig builds the adapter configuration from the transport flags
+func connectionConfig() (adapter.Config, error) {
+	headers, err := parseHeaders(connectHeaders, bearerToken)
+	if err != nil {
+		return adapter.Config{}, err
+	}
+
 	config := adapter.Config{
+		Headers:   headers,
+		OAuth:     oauthOptions(),
 		ServerURL: connectURL,
 		Command:   connectCommand,
 		Args:      connectArgs,
 		Env:       connectEnv,
+		StderrLog: stderrLog,
 		Timeout:   connectTimeout,
 		Verbose:   verbose,
+
+		Pagination: paginationOptions(),
 	}
 
 	// Parse command string if provided as a single argument
@@ -86,46 +301,144 @@ func runConnectCommand(cmd *cobra.Command, args []string) error {
 		config.Args = expandedArgs
 	}
 
+	return config, nil
+}
+
+// connectToServer creates the adapter selected by the transport flags, or
+// the named server given with --server, and connects it. Callers are
+// responsible for disconnecting.
+func connectToServer(ctx context.Context) (adapter.ServerAdapter, error) {
+	if err := validatePagination(); err != nil {
+		return nil, err
+	}
+
 	// Create the appropriate adapter
-	adapterType := adapter.AdapterType(connectType)
-	serverAdapter, err := adapter.NewAdapter(adapterType, config)
+	serverAdapter, adapterType, err := createAdapter()
 	if err != nil {
-		return fmt.Errorf("failed to create adapter: %w", err)
+		return nil, fmt.Errorf("failed to create adapter: %w", err)
+	}
+	connectedTransport = adapterType
+	if notificationHandler != nil {
+		serverAdapter.OnNotification(notificationHandler)
+	}
+	if samplingHandler != nil {
+		serverAdapter.SetSamplingHandler(samplingHandler)
 	}
+	if elicitationHandler != nil {
+		serverAdapter.SetElicitationHandler(elicitationHandler)
+	}
+	if serverRoots, err = newRoots(connectRoots); err != nil {
+		return nil, err
+	}
+	serverAdapter.SetRoots(serverRoots)
 
-	// Connect to the server
 	if verbose {
-		fmt.Printf("Connecting to MCP server using %s transport...\n", connectType)
+		fmt.Fprintf(os.Stderr, "Connecting to MCP server using %s transport...\n", adapterType)
 	}
 
 	if err := serverAdapter.Connect(ctx); err != nil {
-		return fmt.Errorf("failed to connect to server: %w", err)
+		return nil, fmt.Errorf("failed to connect to server: %w", err)
 	}
 
-	defer func() {
-		if err := serverAdapter.Disconnect(); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to disconnect: %v\n", err)
+	if verbose && connectedTransport == adapter.AdapterTypeAuto {
+		fmt.Fprintf(os.Stderr, "Negotiated transport: %s\n", transportName(serverAdapter))
+	}
+
+	return serverAdapter, nil
+}
+
+// createAdapter creates the adapter of the named server or the registry
+// server given with --registry or, when neither is given, the one described
+// by the transport flags
+func createAdapter() (adapter.ServerAdapter, adapter.AdapterType, error) {
+	if registryServer != "" {
+		if serverName != "" {
+			return nil, "", fmt.Errorf("--registry cannot be used together with a configured server name")
 		}
-	}()
+		return createRegistryAdapter()
+	}
 
-	// Get server information
-	serverInfo, err := serverAdapter.GetServerInfo()
+	if serverName == "" {
+		adapterConfig, err := connectionConfig()
+		if err != nil {
+			return nil, "", err
+		}
+
+		adapterType := adapter.AdapterType(connectType)
+		serverAdapter, err := adapter.NewAdapter(adapterType, adapterConfig)
+		return serverAdapter, adapterType, err
+	}
+
+	file, err := config.Load(configPath)
 	if err != nil {
-		return fmt.Errorf("failed to get server info: %w", err)
+		return nil, "", err
 	}
+	server, err := file.Server(serverName)
+	if err != nil {
+		return nil, "", err
+	}
+	return createServerAdapter(server, nil)
+}
 
-	fmt.Printf("✓ Connected to MCP server: %s (version %s)\n\n",
-		serverInfo.Name, serverInfo.Version)
+// createServerAdapter creates the adapter of a configuration file entry.
+// Headers given on the command line are added to the configured ones, and
+// secrets are masked in the adapter logs.
+func createServerAdapter(server config.Server, secrets []string) (adapter.ServerAdapter, adapter.AdapterType, error) {
+	factoryConfig := server.FactoryConfig()
+	factoryConfig["verbose"] = verbose
+	factoryConfig["secrets"] = secrets
+	factoryConfig["oauth"] = oauthOptions()
+	factoryConfig["pagination"] = paginationOptions()
+	if server.Timeout == "" {
+		factoryConfig["timeout"] = connectTimeout
+	}
+	if stderrLog != "" {
+		factoryConfig["stderr_log"] = stderrLog
+	}
 
-	if interactiveMode {
-		return runInteractiveMode(ctx, serverAdapter)
+	flagHeaders, err := parseHeaders(connectHeaders, bearerToken)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(flagHeaders) > 0 {
+		headers := make(map[string]string, len(server.Headers)+len(flagHeaders))
+		for name, value := range server.Headers {
+			headers[name] = value
+		}
+		for name, value := range flagHeaders {
+			headers[name] = value
+		}
+		factoryConfig["headers"] = headers
 	}
 
-	// Default: show server capabilities
-	return showServerCapabilities(ctx, serverAdapter)
+	serverAdapter, err := adapter.NewAdapterFactory().CreateFromConfig(factoryConfig)
+	return serverAdapter, adapter.AdapterType(server.TransportType()), err
+}
+
+// transportName returns the transport used by a connected adapter, which is
+// the negotiated one for --type auto
+func transportName(serverAdapter adapter.ServerAdapter) string {
+	if autoAdapter, ok := serverAdapter.(*adapter.AutoAdapter); ok {
+		return string(autoAdapter.NegotiatedTransport())
+	}
+	return string(connectedTransport)
+}
+
+// serverCapabilities is the structured output of the connect command
+type serverCapabilities struct {
+	Server            *mcp.Implementation    `json:"server"`
+	Transport         string                 `json:"transport"`
+	Tools             []mcp.Tool             `json:"tools"`
+	Resources         []mcp.Resource         `json:"resources"`
+	ResourceTemplates []mcp.ResourceTemplate `json:"resource_templates"`
+	Prompts           []mcp.Prompt           `json:"prompts"`
 }
 
 func showServerCapabilities(ctx context.Context, adapter adapter.ServerAdapter) error {
+	if format := selectedOutputFormat(); format.IsStructured() {
+		return writeServerCapabilities(ctx, adapter, format)
+	}
+
 	fmt.Println("Server Capabilities:")
 	fmt.Println("===================")
 
@@ -144,11 +457,7 @@ func showServerCapabilities(ctx context.Context, adapter adapter.ServerAdapter)
 				return err
 			}
 			for _, tool := range tools {
-				description := tool.Description
-				if len(description) > 60 {
-					description = description[:57] + "..."
-				}
-				if _, err := fmt.Fprintf(w, "%s\t%s\n", tool.Name, description); err != nil {
+				if _, err := fmt.Fprintf(w, "%s\t%s\n", tool.Name, truncateText(tool.Description, 60)); err != nil {
 					return err
 				}
 			}
@@ -175,11 +484,7 @@ func showServerCapabilities(ctx context.Context, adapter adapter.ServerAdapter)
 				return err
 			}
 			for _, resource := range resources {
-				description := resource.Description
-				if len(description) > 50 {
-					description = description[:47] + "..."
-				}
-				if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", resource.URI, resource.Name, description); err != nil {
+				if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", resource.URI, resource.Name, truncateText(resource.Description, 50)); err != nil {
 					return err
 				}
 			}
@@ -191,6 +496,33 @@ func showServerCapabilities(ctx context.Context, adapter adapter.ServerAdapter)
 		}
 	}
 
+	// Show resource templates
+	templates, err := adapter.ListResourceTemplates(ctx)
+	if err != nil {
+		fmt.Printf("Failed to list resource templates: %v\n", err)
+	} else {
+		fmt.Printf("\nResource templates (%d available):\n", len(templates))
+		if len(templates) > 0 {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			if _, err := fmt.Fprintln(w, "NAME\tURI TEMPLATE\tDESCRIPTION"); err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintln(w, "----\t------------\t-----------"); err != nil {
+				return err
+			}
+			for _, template := range templates {
+				if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", template.Name, templateURI(template), truncateText(template.Description, 50)); err != nil {
+					return err
+				}
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		} else {
+			fmt.Println("  No resource templates available")
+		}
+	}
+
 	// Show prompts
 	prompts, err := adapter.ListPrompts(ctx)
 	if err != nil {
@@ -207,12 +539,7 @@ func showServerCapabilities(ctx context.Context, adapter adapter.ServerAdapter)
 				return err
 			}
 			for _, prompt := range prompts {
-				description := prompt.Description
-				if len(description) > 60 {
-					description = description[:57] + "..."
-				}
-
-				if _, err := fmt.Fprintf(w, "%s\t%s\n", prompt.Name, description); err != nil {
+				if _, err := fmt.Fprintf(w, "%s\t%s\n", prompt.Name, truncateText(prompt.Description, 60)); err != nil {
 					return err
 				}
 			}
@@ -227,66 +554,142 @@ func showServerCapabilities(ctx context.Context, adapter adapter.ServerAdapter)
 	return nil
 }
 
+// writeServerCapabilities writes the server information and the full tool,
+// resource, resource template and prompt lists in a structured format. Lists the server does
+// not support are reported on stderr and left empty.
+func writeServerCapabilities(ctx context.Context, adapter adapter.ServerAdapter, format output.Format) error {
+	serverInfo, err := adapter.GetServerInfo()
+	if err != nil {
+		return fmt.Errorf("failed to get server info: %w", err)
+	}
+
+	capabilities := serverCapabilities{
+		Server:            serverInfo,
+		Transport:         transportName(adapter),
+		Tools:             []mcp.Tool{},
+		Resources:         []mcp.Resource{},
+		ResourceTemplates: []mcp.ResourceTemplate{},
+		Prompts:           []mcp.Prompt{},
+	}
+
+	if tools, err := adapter.ListTools(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to list tools: %v\n", err)
+	} else if tools != nil {
+		capabilities.Tools = tools
+	}
+
+	if resources, err := adapter.ListResources(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to list resources: %v\n", err)
+	} else if resources != nil {
+		capabilities.Resources = resources
+	}
+
+	if templates, err := adapter.ListResourceTemplates(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to list resource templates: %v\n", err)
+	} else if templates != nil {
+		capabilities.ResourceTemplates = templates
+	}
+
+	if prompts, err := adapter.ListPrompts(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to list prompts: %v\n", err)
+	} else if prompts != nil {
+		capabilities.Prompts = prompts
+	}
+
+	return output.Write(os.Stdout, format, capabilities)
+}
+
 func runInteractiveMode(ctx context.Context, adapter adapter.ServerAdapter) error {
 	fmt.Println("Interactive Mode - Type 'help' for available commands")
 	fmt.Println("====================================================")
 
-	// Use bufio.Scanner for better input handling
-	scanner := bufio.NewScanner(os.Stdin)
+	completer := &replCompleter{ctx: ctx, adapter: adapter, listings: completionListings}
+	reader := newLineReader(completer.complete, openHistory())
 
 	for {
-		fmt.Print("> ")
-
-		if !scanner.Scan() {
-			break
+		line, err := reader.ReadLine()
+		if errors.Is(err, io.EOF) {
+			return nil
 		}
-
-		input := strings.TrimSpace(scanner.Text())
-		if input == "" {
-			continue
+		if err != nil {
+			return fmt.Errorf("error reading input: %w", err)
 		}
 
-		parts := strings.Fields(input)
-		if len(parts) == 0 {
-			continue
+		quit, err := runReplCommand(ctx, adapter, line)
+		// Failed tool results are shown with the result
+		if err != nil && !errors.Is(err, errToolResult) {
+			fmt.Printf("Error: %v\n", err)
 		}
-
-		command := parts[0]
-
-		switch command {
-		case "help":
-			showInteractiveHelp()
-		case "tools":
-			listToolsInteractive(ctx, adapter)
-		case "resources":
-			listResourcesInteractive(ctx, adapter)
-		case "prompts":
-			listPromptsInteractive(ctx, adapter)
-		case "call":
-			if len(parts) < 2 {
-				fmt.Println("Usage: call <tool-name> [arguments...]")
-				continue
-			}
-			callToolInteractive(ctx, adapter, parts[1], parts[2:])
-		case "read":
-			if len(parts) < 2 {
-				fmt.Println("Usage: read <resource-uri>")
-				continue
-			}
-			readResourceInteractive(ctx, adapter, parts[1])
-		case "quit", "exit":
-			fmt.Println("Goodbye!")
+		if quit {
 			return nil
-		default:
-			fmt.Printf("Unknown command: %s (type 'help' for available commands)\n", command)
 		}
 	}
+}
 
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("error reading input: %w", err)
+// runReplCommand runs a command line of interactive mode or of a script. It
+// reports whether the session ends.
+func runReplCommand(ctx context.Context, adapter adapter.ServerAdapter, line string) (bool, error) {
+	parts, err := splitCommandLine(strings.TrimSpace(line))
+	if err != nil {
+		return false, err
+	}
+	if len(parts) == 0 {
+		return false, nil
 	}
 
-	return nil
+	command := parts[0]
+
+	switch command {
+	case "help":
+		showInteractiveHelp()
+		return false, nil
+	case "tools":
+		return false, listToolsInteractive(ctx, adapter)
+	case "resources":
+		return false, listResourcesInteractive(ctx, adapter)
+	case "templates":
+		return false, listResourceTemplatesInteractive(ctx, adapter)
+	case "prompts":
+		return false, listPromptsInteractive(ctx, adapter)
+	case "call":
+		if len(parts) < 2 {
+			return false, fmt.Errorf("missing tool name (usage: call <tool-name> [arguments...])")
+		}
+		return false, callToolInteractive(ctx, adapter, parts[1], parts[2:])
+	case "prompt":
+		if len(parts) < 2 {
+			return false, fmt.Errorf("missing prompt name (usage: prompt <prompt-name> [arguments...])")
+		}
+		return false, getPromptInteractive(ctx, adapter, parts[1], parts[2:])
+	case "read":
+		if len(parts) < 2 {
+			return false, fmt.Errorf("missing resource (usage: read <resource-uri> | read <template-name> [key=value...])")
+		}
+		uri, err := resolveResourceURI(ctx, adapter, parts[1], parts[2:])
+		if err != nil {
+			return false, err
+		}
+		return false, readResourceInteractive(ctx, adapter, uri)
+	case "watch":
+		if len(parts) < 2 {
+			return false, fmt.Errorf("missing resource (usage: watch <resource-uri> | watch <template-name> [key=value...])")
+		}
+		return false, watchResourceInteractive(ctx, adapter, parts[1], parts[2:])
+	case "roots":
+		return false, rootsInteractive(ctx, adapter, serverRoots, parts[1:])
+	case "stderr":
+		return false, stderrInteractive(adapter, parts[1:])
+	case "loglevel":
+		if len(parts) < 2 {
+			return false, fmt.Errorf("missing level (usage: loglevel <debug|info|notice|warning|error|critical|alert|emergency>)")
+		}
+		return false, setLogLevelInteractive(ctx, adapter, parts[1])
+	case "quit", "exit":
+		fmt.Println("Goodbye!")
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown command: %s (type 'help' for available commands)", command)
+	}
 }
 
 func showInteractiveHelp() {
@@ -294,128 +697,194 @@ func showInteractiveHelp() {
 	fmt.Println("  help                                    - Show this help message")
 	fmt.Println("  tools                                   - List available tools")
 	fmt.Println("  resources                               - List available resources")
+	fmt.Println("  templates                               - List available resource templates")
 	fmt.Println("  prompts                                 - List available prompts")
-	fmt.Println("  call <tool-name> [arguments...]         - Call a tool with arguments")
+	fmt.Println("  call <tool-name> [arguments...]         - Call a tool with arguments, Ctrl+C cancels it")
+	fmt.Println("  prompt <prompt-name> [arguments...]     - Get a prompt with arguments")
 	fmt.Println("  read <uri>                              - Read a resource")
+	fmt.Println("  read <template-name> [key=value...]     - Read a resource from a template")
+	fmt.Println("  watch <uri>                             - Show the changes of a resource until Ctrl+C")
+	fmt.Println("  loglevel <level>                        - Set the minimum level of server log messages")
+	fmt.Println("  roots                                   - List the roots offered to the server")
+	fmt.Println("  roots add|remove <path-or-uri>          - Change the roots and notify the server")
+	fmt.Println("  stderr [lines]                          - Show the last output of a stdio server on stderr")
 	fmt.Println("  quit, exit                              - Exit interactive mode")
 	fmt.Println()
-	fmt.Println("Examples for API Linter tools:")
-	fmt.Println("  call list_rules                         - List all API Linter rules")
-	fmt.Println("  call get_rule_categories                - Get rule categories")
-	fmt.Println("  call get_api_docs                       - Get API design guide")
-	fmt.Println("  call validate_api_specification <spec>  - Validate OpenAPI spec")
-	fmt.Println("  call calculate_api_quality_score <spec> - Calculate quality score")
+	fmt.Println("Tab completes commands, names and arguments; values of prompt arguments and")
+	fmt.Println("template variables are completed by the server when it supports completions.")
+	fmt.Println("Up and Down browse the history of the server, Ctrl+R searches it, and a command")
+	fmt.Println("continues on the next line while braces or brackets are open.")
+	fmt.Println()
+	fmt.Println("Tool arguments are parsed using the tool's input schema:")
+	fmt.Println("  call echo hello                         - Positional values fill required arguments in order")
+	fmt.Println("  call search query=mcp limit=5           - key=value pairs are converted to the declared type")
+	fmt.Println("  call tag ids=1,2,3 opts='{\"a\": true}'   - Arrays as lists or JSON, objects as inline JSON")
+	fmt.Println("  call create '{\"name\": \"x\"}'             - A JSON object with all arguments")
+	fmt.Println()
+	fmt.Println("Prompt arguments are checked against the prompt's declared arguments:")
+	fmt.Println("  prompt summarize notes.txt              - Positional values fill required arguments in order")
+	fmt.Println("  prompt review language=go               - key=value pairs set any declared argument")
+	fmt.Println()
+	fmt.Println("Resource templates are expanded following RFC 6570:")
+	fmt.Println("  read user-profile id=42                 - Fill the {id} variable of the template")
+	fmt.Println("  read search tag=a tag=b                 - Repeated variables become lists")
 	fmt.Println()
 }
 
-func listToolsInteractive(ctx context.Context, adapter adapter.ServerAdapter) {
+func listToolsInteractive(ctx context.Context, adapter adapter.ServerAdapter) error {
 	tools, err := adapter.ListTools(ctx)
 	if err != nil {
-		fmt.Printf("Error listing tools: %v\n", err)
-		return
+		return err
 	}
 
 	if len(tools) == 0 {
 		fmt.Println("No tools available")
-		return
+		return nil
 	}
 
 	fmt.Printf("Available tools (%d):\n", len(tools))
 	for i, tool := range tools {
 		fmt.Printf("%d. %s - %s\n", i+1, tool.Name, tool.Description)
 	}
+	return nil
 }
 
-func listResourcesInteractive(ctx context.Context, adapter adapter.ServerAdapter) {
+func listResourcesInteractive(ctx context.Context, adapter adapter.ServerAdapter) error {
 	resources, err := adapter.ListResources(ctx)
 	if err != nil {
-		fmt.Printf("Error listing resources: %v\n", err)
-		return
+		return err
 	}
 
 	if len(resources) == 0 {
 		fmt.Println("No resources available")
-		return
+		return nil
 	}
 
 	fmt.Printf("Available resources (%d):\n", len(resources))
 	for i, resource := range resources {
 		fmt.Printf("%d. %s (%s) - %s\n", i+1, resource.URI, resource.Name, resource.Description)
 	}
+	return nil
 }
 
-func listPromptsInteractive(ctx context.Context, adapter adapter.ServerAdapter) {
+func listPromptsInteractive(ctx context.Context, adapter adapter.ServerAdapter) error {
 	prompts, err := adapter.ListPrompts(ctx)
 	if err != nil {
-		fmt.Printf("Error listing prompts: %v\n", err)
-		return
+		return err
 	}
 
 	if len(prompts) == 0 {
 		fmt.Println("No prompts available")
-		return
+		return nil
 	}
 
 	fmt.Printf("Available prompts (%d):\n", len(prompts))
 	for i, prompt := range prompts {
 		fmt.Printf("%d. %s - %s\n", i+1, prompt.Name, prompt.Description)
+		if len(prompt.Arguments) > 0 {
+			fmt.Printf("   Usage: %s\n", promptUsage(prompt))
+		}
 	}
+	return nil
 }
 
-func callToolInteractive(ctx context.Context, adapter adapter.ServerAdapter, toolName string, args []string) {
-	// Handle different argument patterns based on the tool
-	arguments := make(map[string]any)
+func getPromptInteractive(ctx context.Context, adapter adapter.ServerAdapter, promptName string, args []string) error {
+	prompts, err := adapter.ListPrompts(ctx)
+	if err != nil {
+		return err
+	}
 
-	switch toolName {
-	case "list_rules", "get_rule_categories", "get_api_docs", "get_api_linter_link":
-		// These tools typically don't need arguments
-		// Keep arguments empty
+	prompt, ok := findPrompt(prompts, promptName)
+	if !ok {
+		return fmt.Errorf("unknown prompt: %s (type 'prompts' to list available prompts)", promptName)
+	}
 
-	case "validate_api_specification":
-		if len(args) > 0 {
-			arguments["specification"] = args[0]
-		} else {
-			fmt.Println("Usage: call validate_api_specification <specification_content_or_url>")
-			return
-		}
-		if len(args) > 1 {
-			arguments["format"] = args[1] // e.g., "openapi", "swagger"
-		}
+	arguments, err := parsePromptArguments(prompt, args)
+	if err != nil {
+		fmt.Printf("Usage: %s\n", promptUsage(prompt))
+		showPromptArguments(prompt)
+		return err
+	}
 
-	case "calculate_api_quality_score":
-		if len(args) > 0 {
-			arguments["specification"] = args[0]
-		} else {
-			fmt.Println("Usage: call calculate_api_quality_score <specification_content_or_url>")
-			return
+	if verbose {
+		fmt.Printf("Getting prompt '%s' with arguments: %+v\n", promptName, arguments)
+	}
+
+	result, err := adapter.GetPrompt(ctx, promptName, arguments)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Prompt '%s' (%d messages):\n", promptName, len(result.Messages))
+	if err := renderPromptResult(os.Stdout, result); err != nil {
+		return err
+	}
+	fmt.Println()
+	return nil
+}
+
+// showPromptArguments describes the arguments declared by a prompt
+func showPromptArguments(prompt mcp.Prompt) {
+	if len(prompt.Arguments) == 0 {
+		return
+	}
+	fmt.Println("Arguments:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, argument := range prompt.Arguments {
+		required := "optional"
+		if argument.Required {
+			required = "required"
 		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", argument.Name, required, argument.Description)
+	}
+	_ = w.Flush()
+}
 
-	default:
-		// For other tools, use generic argument parsing
-		if len(args) == 1 && (strings.HasPrefix(args[0], "{") || strings.Contains(args[0], ":")) {
-			// Try to parse as JSON-like input
-			arguments["input"] = args[0]
-		} else {
-			// Simple key=value or positional arguments
-			for i, arg := range args {
-				if strings.Contains(arg, "=") {
-					parts := strings.SplitN(arg, "=", 2)
-					arguments[parts[0]] = parts[1]
-				} else {
-					arguments[fmt.Sprintf("arg%d", i)] = arg
-				}
-			}
+// findPrompt looks up a prompt by name
+func findPrompt(prompts []mcp.Prompt, name string) (mcp.Prompt, bool) {
+	for _, prompt := range prompts {
+		if prompt.Name == name {
+			return prompt, true
 		}
 	}
+	return mcp.Prompt{}, false
+}
+
+// errToolResult is returned for the tool results flagged as errors
+var errToolResult = errors.New("tool execution resulted in an error")
+
+func callToolInteractive(ctx context.Context, adapter adapter.ServerAdapter, toolName string, args []string) error {
+	tools, err := adapter.ListTools(ctx)
+	if err != nil {
+		return err
+	}
+
+	tool, ok := findTool(tools, toolName)
+	if !ok {
+		return fmt.Errorf("unknown tool: %s (type 'tools' to list available tools)", toolName)
+	}
+
+	arguments, err := parseToolArguments(tool.InputSchema, args)
+	if err != nil {
+		fmt.Printf("Usage: %s\n", toolUsage(tool))
+		return err
+	}
 
 	if verbose {
 		fmt.Printf("Calling tool '%s' with arguments: %+v\n", toolName, arguments)
 	}
 
-	result, err := adapter.CallTool(ctx, toolName, arguments)
+	// Ctrl+C cancels the call on the server and returns to the prompt
+	callCtx, stop := interruptible(ctx)
+	result, err := adapter.CallTool(toolProgress.start(callCtx), toolName, arguments)
+	stop()
+	toolProgress.done()
 	if err != nil {
-		fmt.Printf("Error calling tool %s: %v\n", toolName, err)
-		return
+		if errors.Is(context.Cause(callCtx), errInterrupted) {
+			fmt.Println()
+			return fmt.Errorf("call to tool %s cancelled", toolName)
+		}
+		return err
 	}
 
 	fmt.Printf("Tool '%s' result:\n", toolName)
@@ -454,13 +923,27 @@ func callToolInteractive(ctx context.Context, adapter adapter.ServerAdapter, too
 		}
 	}
 	fmt.Println()
+
+	if result.IsError {
+		return fmt.Errorf("tool %s: %w", toolName, errToolResult)
+	}
+	return nil
+}
+
+// findTool looks up a tool by name
+func findTool(tools []mcp.Tool, name string) (mcp.Tool, bool) {
+	for _, tool := range tools {
+		if tool.Name == name {
+			return tool, true
+		}
+	}
+	return mcp.Tool{}, false
 }
 
-func readResourceInteractive(ctx context.Context, adapter adapter.ServerAdapter, uri string) {
+func readResourceInteractive(ctx context.Context, adapter adapter.ServerAdapter, uri string) error {
 	result, err := adapter.ReadResource(ctx, uri)
 	if err != nil {
-		fmt.Printf("Error reading resource %s: %v\n", uri, err)
-		return
+		return err
 	}
 
 	fmt.Printf("Resource content:\n")
@@ -474,16 +957,42 @@ func readResourceInteractive(ctx context.Context, adapter adapter.ServerAdapter,
 			fmt.Printf("  Content: %+v\n", c)
 		}
 	}
+	return nil
 }
 
 func init() {
 	rootCmd.AddCommand(connectCmd)
 
-	connectCmd.Flags().StringVar(&connectType, "type", "stdio", "Transport type (stdio, http, streamable)")
-	connectCmd.Flags().StringVar(&connectURL, "url", "", "Server URL for HTTP-based connections")
-	connectCmd.Flags().StringVar(&connectCommand, "command", "", "Command to execute for stdio connections")
-	connectCmd.Flags().StringArrayVar(&connectArgs, "args", nil, "Arguments for the command")
-	connectCmd.Flags().StringArrayVar(&connectEnv, "env", nil, "Environment variables for the command")
-	connectCmd.Flags().DurationVar(&connectTimeout, "timeout", 60*time.Second, "Connection timeout")
+	addTransportFlags(connectCmd)
 	connectCmd.Flags().BoolVar(&interactiveMode, "interactive", false, "Run in interactive mode")
+	connectCmd.Flags().StringVar(&connectScript, "script", "", "Run the interactive mode commands of a file (\"-\" for stdin) and exit")
+	connectCmd.Flags().BoolVar(&continueOnError, "continue-on-error", false, "Keep running a script after a command fails")
+	connectCmd.Flags().BoolVar(&followMode, "follow", false, "Print server notifications as they arrive until interrupted")
+	connectCmd.Flags().StringVar(&connectSampling, "sampling", "", "Answer sampling requests of the server: \"interactive\" or a YAML file of scripted responses")
+	addElicitationFlags(connectCmd)
+	connectCmd.Flags().StringVar(&connectLogLevel, "log-level", "", "Minimum level of the log messages sent by the server (debug, info, notice, warning, error, critical, alert, emergency)")
+	connectCmd.Flags().StringVar(&registryServer, "registry", "", "Name of an MCP Registry server to connect to")
+	connectCmd.Flags().StringVar(&registryURL, "registry-url", "", "Base URL of the MCP Registry Service used with --registry (default http://localhost:8080)")
+	connectCmd.Flags().IntVar(&registryRemote, "remote", 0, "Remote of the registry server to connect to, as numbered by \"get server\"")
+	connectCmd.Flags().StringArrayVar(&inputValues, "input", nil, "Value of a registry server input as name=value (can be repeated)")
+	connectCmd.Flags().IntVar(&registryPackage, "package", 0, "Package of the registry server to run, as numbered by \"get server\"")
+}
+
+// addTransportFlags registers the flags selecting and configuring the server
+// transport. They are shared by connect and the one-shot call, read and
+// prompt commands.
+func addTransportFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&serverName, "server", "", "Name of a server defined in the configuration file")
+	cmd.Flags().StringVar(&connectType, "type", "stdio", "Transport type (stdio, http, streamable, sse, auto)")
+	cmd.Flags().StringVar(&connectURL, "url", "", "Server URL for HTTP-based connections")
+	cmd.Flags().StringVar(&connectCommand, "command", "", "Command to execute for stdio connections")
+	cmd.Flags().StringArrayVar(&connectArgs, "args", nil, "Arguments for the command")
+	cmd.Flags().StringArrayVar(&connectEnv, "env", nil, "Environment variables for the command")
+	cmd.Flags().StringVar(&stderrLog, "stderr-log", "", "File the stderr output of stdio servers is appended to")
+	cmd.Flags().StringArrayVar(&connectHeaders, "header", nil, "HTTP header as \"Name: value\" (can be repeated, values may reference ${ENV_VAR})")
+	cmd.Flags().StringVar(&bearerToken, "bearer-token", "", "Bearer token sent in the Authorization header of HTTP requests (may reference ${ENV_VAR})")
+	cmd.Flags().StringArrayVar(&connectRoots, "root", nil, "Directory or file:// URI offered to the server as a root (can be repeated, default: the working directory)")
+	cmd.Flags().DurationVar(&connectTimeout, "timeout", 60*time.Second, "Connection timeout")
+	addOAuthFlags(cmd)
+	addPaginationFlags(cmd)
 }
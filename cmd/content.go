@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// writeToolResult writes the content of a tool result in a plain form
+// suitable for scripts: text is written as-is, other content is summarized.
+func writeToolResult(w io.Writer, result *mcp.CallToolResult) error {
+	for _, content := range result.Content {
+		if err := writeContent(w, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeContent writes a single content item
+func writeContent(w io.Writer, content mcp.Content) error {
+	var err error
+	switch c := content.(type) {
+	case mcp.TextContent:
+		_, err = fmt.Fprintln(w, strings.TrimRight(c.Text, "\n"))
+	case mcp.ImageContent:
+		_, err = fmt.Fprintf(w, "[image %s, %d bytes base64]\n", c.MIMEType, len(c.Data))
+	case mcp.AudioContent:
+		_, err = fmt.Fprintf(w, "[audio %s, %d bytes base64]\n", c.MIMEType, len(c.Data))
+	case mcp.EmbeddedResource:
+		err = writeResourceContents(w, []mcp.ResourceContents{c.Resource})
+	default:
+		_, err = fmt.Fprintf(w, "%+v\n", c)
+	}
+	return err
+}
+
+// writeResourceContents writes resource contents: text as-is and blobs
+// decoded so the output can be redirected to a file
+func writeResourceContents(w io.Writer, contents []mcp.ResourceContents) error {
+	for _, content := range contents {
+		switch c := content.(type) {
+		case mcp.TextResourceContents:
+			if _, err := io.WriteString(w, c.Text); err != nil {
+				return err
+			}
+			if !strings.HasSuffix(c.Text, "\n") {
+				if _, err := io.WriteString(w, "\n"); err != nil {
+					return err
+				}
+			}
+		case mcp.BlobResourceContents:
+			data, err := base64.StdEncoding.DecodeString(c.Blob)
+			if err != nil {
+				return fmt.Errorf("failed to decode blob content of %s: %w", c.URI, err)
+			}
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+		default:
+			if _, err := fmt.Fprintf(w, "%+v\n", c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writePromptResult writes the messages of a prompt, each prefixed by its role
+func writePromptResult(w io.Writer, result *mcp.GetPromptResult) error {
+	for i, message := range result.Messages {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "[%s]\n", message.Role); err != nil {
+			return err
+		}
+		if err := writeContent(w, message.Content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderPromptResult renders the messages of a prompt for interactive mode:
+// each message under a heading with its role, text indented and other
+// content summarized
+func renderPromptResult(w io.Writer, result *mcp.GetPromptResult) error {
+	if result.Description != "" {
+		if _, err := fmt.Fprintf(w, "%s\n\n", result.Description); err != nil {
+			return err
+		}
+	}
+	if len(result.Messages) == 0 {
+		_, err := fmt.Fprintln(w, "No messages")
+		return err
+	}
+
+	for i, message := range result.Messages {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s:\n", roleLabel(message.Role)); err != nil {
+			return err
+		}
+		if err := renderContent(w, message.Content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// roleLabel capitalizes a message role for display
+func roleLabel(role mcp.Role) string {
+	if role == "" {
+		return "Unknown"
+	}
+	return strings.ToUpper(string(role[:1])) + string(role[1:])
+}
+
+// renderContent renders a content item indented under its message
+func renderContent(w io.Writer, content mcp.Content) error {
+	var err error
+	switch c := content.(type) {
+	case mcp.TextContent:
+		err = writeIndented(w, c.Text)
+	case mcp.ImageContent:
+		_, err = fmt.Fprintf(w, "  [image %s, %s]\n", c.MIMEType, formatBytes(decodedSize(c.Data)))
+	case mcp.AudioContent:
+		_, err = fmt.Fprintf(w, "  [audio %s, %s]\n", c.MIMEType, formatBytes(decodedSize(c.Data)))
+	case mcp.EmbeddedResource:
+		switch r := c.Resource.(type) {
+		case mcp.TextResourceContents:
+			if _, err = fmt.Fprintf(w, "  [resource %s%s]\n", r.URI, mimeSuffix(r.MIMEType)); err == nil {
+				err = writeIndented(w, r.Text)
+			}
+		case mcp.BlobResourceContents:
+			_, err = fmt.Fprintf(w, "  [resource %s%s, %s]\n", r.URI, mimeSuffix(r.MIMEType), formatBytes(decodedSize(r.Blob)))
+		default:
+			_, err = fmt.Fprintf(w, "  [resource %+v]\n", r)
+		}
+	default:
+		_, err = fmt.Fprintf(w, "  %+v\n", c)
+	}
+	return err
+}
+
+// writeIndented writes text with each line indented
+func writeIndented(w io.Writer, text string) error {
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		if _, err := fmt.Fprintf(w, "  %s\n", line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mimeSuffix(mimeType string) string {
+	if mimeType == "" {
+		return ""
+	}
+	return ", " + mimeType
+}
+
+// decodedSize returns the size of base64 encoded data once decoded
+func decodedSize(data string) int {
+	return base64.StdEncoding.DecodedLen(len(data)) - (len(data) - len(strings.TrimRight(data, "=")))
+}
+
+// formatBytes formats a size in bytes for display
+func formatBytes(n int) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d bytes", n)
+	}
+}
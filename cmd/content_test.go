@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderPromptResult(t *testing.T) {
+	data := base64.StdEncoding.EncodeToString(make([]byte, 2048))
+	result := &mcp.GetPromptResult{
+		Description: "Code review",
+		Messages: []mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent("Review this:\nfunc main() {}\n")),
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewImageContent(data, "image/png")),
+			mcp.NewPromptMessage(mcp.RoleAssistant, mcp.NewAudioContent("AAA=", "audio/wav")),
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewEmbeddedResource(mcp.TextResourceContents{URI: "file:///main.go", MIMEType: "text/x-go", Text: "package main"})),
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewEmbeddedResource(mcp.BlobResourceContents{URI: "file:///logo.png", Blob: "AAAA"})),
+		},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, renderPromptResult(&out, result))
+	assert.Equal(t, `Code review
+
+User:
+  Review this:
+  func main() {}
+
+User:
+  [image image/png, 2.0 KiB]
+
+Assistant:
+  [audio audio/wav, 2 bytes]
+
+User:
+  [resource file:///main.go, text/x-go]
+  package main
+
+User:
+  [resource file:///logo.png, 3 bytes]
+`, out.String())
+}
+
+func TestRenderPromptResultEmpty(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, renderPromptResult(&out, &mcp.GetPromptResult{}))
+	assert.Equal(t, "No messages\n", out.String())
+}
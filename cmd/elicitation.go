@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+	"github.com/jbovet/mcp-cli/pkg/elicitation"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	// elicitationAnswers is the --elicitation-answers flag
+	elicitationAnswers string
+
+	// elicitationHandler is set by connectToServer before connecting
+	elicitationHandler adapter.ElicitationHandler
+)
+
+// newElicitationHandler answers elicitation requests from the file given with
+// --elicitation-answers or, when interactive is set, with a terminal form
+func newElicitationHandler(interactive bool) (adapter.ElicitationHandler, error) {
+	if elicitationAnswers != "" {
+		return elicitation.LoadAnswers(elicitationAnswers)
+	}
+	if interactive {
+		return &elicitationForm{prompter: newPrompter(os.Stdin, os.Stderr)}, nil
+	}
+	return nil, nil
+}
+
+// addElicitationFlags registers the flags answering elicitation requests
+func addElicitationFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&elicitationAnswers, "elicitation-answers", "", "YAML file of scripted answers to the elicitation requests of the server")
+}
+
+// stdinIsTerminal reports whether forms can be shown to the user
+func stdinIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// elicitationForm asks the user for the input requested by the server with a
+// form generated from the requested schema
+type elicitationForm struct {
+	mu       sync.Mutex
+	prompter *prompter
+}
+
+// Elicit implements adapter.ElicitationHandler. Requests are answered one at
+// a time, and in interactive mode only while a command runs. The request is
+// cancelled when the input ends.
+func (f *elicitationForm) Elicit(ctx context.Context, request mcp.ElicitationRequest) (*mcp.ElicitationResult, error) {
+	if err := elicitation.CheckMode(request); err != nil {
+		return nil, err
+	}
+	fields, err := elicitation.Fields(request.Params.RequestedSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := replOutput.startPrompt(); err != nil {
+		return nil, fmt.Errorf("failed to answer elicitation request: %w", err)
+	}
+	defer replOutput.endPrompt()
+
+	out := f.prompter.out
+	if _, err := fmt.Fprintf(out, "\nThe server asks for input:\n"); err != nil {
+		return nil, err
+	}
+	if err := writeIndented(out, request.Params.Message); err != nil {
+		return nil, err
+	}
+
+	action, err := f.choose("Fill in the form? [Y]es, [n]o to decline, [c]ancel: ")
+	if err != nil || action != mcp.ElicitationResponseActionAccept {
+		return f.respond(ctx, action, nil, err)
+	}
+
+	if len(fields) > 0 {
+		fmt.Fprintln(out, "Fields marked with * are required, an empty answer keeps the default.")
+	}
+	content := make(map[string]any, len(fields))
+	for _, field := range fields {
+		value, err := f.ask(field)
+		if err != nil {
+			return f.respond(ctx, mcp.ElicitationResponseActionCancel, nil, err)
+		}
+		if value != nil {
+			content[field.Name] = value
+		}
+	}
+
+	if action, err = f.choose("Send this response? [Y]es, [n]o to decline, [c]ancel: "); err != nil || action != mcp.ElicitationResponseActionAccept {
+		return f.respond(ctx, action, nil, err)
+	}
+	return f.respond(ctx, mcp.ElicitationResponseActionAccept, content, nil)
+}
+
+// respond builds the result of a request. The request is cancelled when the
+// input failed, and the result is dropped when ctx is done.
+func (f *elicitationForm) respond(ctx context.Context, action mcp.ElicitationResponseAction, content map[string]any, err error) (*mcp.ElicitationResult, error) {
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to read elicitation response: %w", err)
+		}
+		action = mcp.ElicitationResponseActionCancel
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("elicitation request expired before it was answered: %w", err)
+	}
+
+	switch action {
+	case mcp.ElicitationResponseActionDecline:
+		fmt.Fprintln(f.prompter.out, "Elicitation request declined")
+	case mcp.ElicitationResponseActionCancel:
+		fmt.Fprintln(f.prompter.out, "Elicitation request cancelled")
+	}
+
+	result := &mcp.ElicitationResult{ElicitationResponse: mcp.ElicitationResponse{Action: action}}
+	if action == mcp.ElicitationResponseActionAccept {
+		result.Content = content
+	}
+	return result, nil
+}
+
+// choose asks whether to accept, decline or cancel, accepting by default
+func (f *elicitationForm) choose(question string) (mcp.ElicitationResponseAction, error) {
+	for {
+		fmt.Fprint(f.prompter.out, question)
+		answer, err := f.prompter.readAnswer(false)
+		if err != nil {
+			return "", err
+		}
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "", "y", "yes":
+			return mcp.ElicitationResponseActionAccept, nil
+		case "n", "no":
+			return mcp.ElicitationResponseActionDecline, nil
+		case "c", "cancel":
+			return mcp.ElicitationResponseActionCancel, nil
+		}
+	}
+}
+
+// ask asks for the value of a field until a valid one is given. It returns
+// nil for an optional field left empty.
+func (f *elicitationForm) ask(field elicitation.Field) (any, error) {
+	out := f.prompter.out
+	if err := writeField(out, field); err != nil {
+		return nil, err
+	}
+
+	for {
+		fmt.Fprint(out, "> ")
+		line, err := f.prompter.readAnswer(false)
+		if err != nil {
+			return nil, err
+		}
+
+		input := strings.TrimSpace(line)
+		if input == "" {
+			if field.Default != nil {
+				return field.Validate(field.Default)
+			}
+			if !field.Required {
+				return nil, nil
+			}
+			fmt.Fprintln(out, "A value is required")
+			continue
+		}
+
+		value, err := field.Parse(choiceValues(field, input))
+		if err != nil {
+			fmt.Fprintf(out, "Invalid value: %v\n", err)
+			continue
+		}
+		return value, nil
+	}
+}
+
+// choiceValues replaces the numbers of the options of a field typed by the
+// user with their values
+func choiceValues(field elicitation.Field, input string) string {
+	if len(field.Options) == 0 {
+		return input
+	}
+
+	parts := []string{input}
+	if field.Type == "array" {
+		parts = strings.Split(input, ",")
+	}
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if n, err := strconv.Atoi(part); err == nil && n >= 1 && n <= len(field.Options) {
+			part = field.Options[n-1].Value
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, ",")
+}
+
+// writeField describes a field of the form: its label, marked with * when
+// required, its type and constraints, and the numbered options of enums
+func writeField(w io.Writer, field elicitation.Field) error {
+	label := field.Label()
+	if field.Required {
+		label += "*"
+	}
+
+	var details []string
+	switch {
+	case field.Type == "array":
+		details = append(details, "choices separated by commas")
+	case len(field.Options) > 0:
+		details = append(details, "choice")
+	case field.Format != "":
+		details = append(details, field.Format)
+	default:
+		details = append(details, field.Type)
+	}
+	if field.Type == "boolean" {
+		details[0] = "yes/no"
+	}
+	if field.Minimum != nil {
+		details = append(details, fmt.Sprintf("min %v", *field.Minimum))
+	}
+	if field.Maximum != nil {
+		details = append(details, fmt.Sprintf("max %v", *field.Maximum))
+	}
+	if field.MinLength != nil {
+		details = append(details, fmt.Sprintf("min %d characters", *field.MinLength))
+	}
+	if field.MaxLength != nil {
+		details = append(details, fmt.Sprintf("max %d characters", *field.MaxLength))
+	}
+	if field.Default != nil {
+		details = append(details, fmt.Sprintf("default: %s", formatDefault(field.Default)))
+	}
+
+	if _, err := fmt.Fprintf(w, "%s (%s)\n", label, strings.Join(details, ", ")); err != nil {
+		return err
+	}
+	if field.Description != "" {
+		if err := writeIndented(w, field.Description); err != nil {
+			return err
+		}
+	}
+	for i, option := range field.Options {
+		text := option.Value
+		if option.Title != "" && option.Title != option.Value {
+			text += " - " + option.Title
+		}
+		if _, err := fmt.Fprintf(w, "  %d. %s\n", i+1, text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatDefault shows a default value as it would be typed
+func formatDefault(value any) string {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return "yes"
+		}
+		return "no"
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(value)
+}
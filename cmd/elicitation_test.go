@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jbovet/mcp-cli/pkg/elicitation"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/term"
+)
+
+func testElicitationRequest() mcp.ElicitationRequest {
+	request := mcp.ElicitationRequest{}
+	request.Params.Message = "Create your account"
+	request.Params.RequestedSchema = map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"name": map[string]any{"type": "string", "description": "Your full name"},
+			"age":  map[string]any{"type": "integer", "minimum": 18.0},
+			"plan": map[string]any{
+				"type":      "string",
+				"enum":      []any{"free", "pro"},
+				"enumNames": []any{"Free", "Professional"},
+				"default":   "free",
+			},
+			"newsletter": map[string]any{"type": "boolean"},
+		},
+		"required": []any{"name", "age"},
+	}
+	return request
+}
+
+func TestElicitationForm(t *testing.T) {
+	answer := func(input string) (*mcp.ElicitationResult, string, error) {
+		var out bytes.Buffer
+		form := &elicitationForm{prompter: newPrompter(strings.NewReader(input), &out)}
+		result, err := form.Elicit(context.Background(), testElicitationRequest())
+		return result, out.String(), err
+	}
+
+	t.Run("accept", func(t *testing.T) {
+		// An empty name is refused, age 12 is below the minimum, newsletter
+		// is left empty and the plan is chosen by number
+		result, out, err := answer("\n\nAda\n12\n36\n\n2\n\n")
+		require.NoError(t, err)
+		assert.Equal(t, mcp.ElicitationResponseActionAccept, result.Action)
+		assert.Equal(t, map[string]any{"name": "Ada", "age": int64(36), "plan": "pro"}, result.Content)
+		assert.Contains(t, out, "A value is required\n")
+		assert.Contains(t, out, "Invalid value: 12 is less than 18\n")
+	})
+
+	t.Run("default", func(t *testing.T) {
+		result, _, err := answer("y\nAda\n36\nyes\n\ny\n")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"name": "Ada", "age": int64(36), "plan": "free", "newsletter": true}, result.Content)
+	})
+
+	t.Run("decline", func(t *testing.T) {
+		result, out, err := answer("n\n")
+		require.NoError(t, err)
+		assert.Equal(t, mcp.ElicitationResponseActionDecline, result.Action)
+		assert.Nil(t, result.Content)
+		assert.Contains(t, out, "Elicitation request declined")
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		result, _, err := answer("\nAda\n36\n\n\nc\n")
+		require.NoError(t, err)
+		assert.Equal(t, mcp.ElicitationResponseActionCancel, result.Action)
+	})
+
+	t.Run("end of input", func(t *testing.T) {
+		result, out, err := answer("\nAda\n")
+		require.NoError(t, err)
+		assert.Equal(t, mcp.ElicitationResponseActionCancel, result.Action)
+		assert.Contains(t, out, "Elicitation request cancelled")
+	})
+}
+
+func TestWriteField(t *testing.T) {
+	fields, err := elicitation.Fields(testElicitationRequest().Params.RequestedSchema)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	for _, field := range fields {
+		require.NoError(t, writeField(&out, field))
+	}
+	assert.Equal(t, `name* (string)
+  Your full name
+age* (integer, min 18)
+newsletter (yes/no)
+plan (choice, default: free)
+  1. free - Free
+  2. pro - Professional
+`, out.String())
+}
+
+func TestElicitationFormDuringCommandRead(t *testing.T) {
+	replOutput.setTerminal(term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{strings.NewReader(""), io.Discard}, replPrompt))
+	defer replOutput.setTerminal(nil)
+
+	var out bytes.Buffer
+	form := &elicitationForm{prompter: newPrompter(strings.NewReader("y\nAda\n36\n\n\ny\n"), &out)}
+	_, err := form.Elicit(context.Background(), testElicitationRequest())
+	assert.ErrorIs(t, err, errCommandPrompt)
+	assert.Empty(t, out.String())
+}
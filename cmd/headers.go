@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jbovet/mcp-cli/pkg/inputs"
+	"github.com/jbovet/mcp-cli/pkg/models"
+)
+
+// parseHeaders builds the HTTP headers given with --header "Name: value" and
+// --bearer-token. Values are passed to the adapter unexpanded, so they can
+// reference environment variables as ${NAME}.
+func parseHeaders(pairs []string, bearerToken string) (map[string]string, error) {
+	headers := make(map[string]string, len(pairs)+1)
+	for _, pair := range pairs {
+		name, value, found := strings.Cut(pair, ":")
+		name = strings.TrimSpace(name)
+		if !found || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("invalid header %q, expected \"Name: value\"", pair)
+		}
+		headers[http.CanonicalHeaderKey(name)] = strings.TrimSpace(value)
+	}
+
+	if bearerToken != "" {
+		if _, exists := headers["Authorization"]; exists {
+			return nil, fmt.Errorf("--bearer-token cannot be used together with an Authorization header")
+		}
+		headers["Authorization"] = "Bearer " + bearerToken
+	}
+
+	return headers, nil
+}
+
+// promptRemoteHeaders asks for the values of the headers declared by a
+// registry remote. Headers already given on the command line are not asked
+// for. Header values may be templates such as "Bearer {api_key}", in which
+// case each variable is asked for and substituted.
+func promptRemoteHeaders(remote models.Remote, headers map[string]string, resolver *inputs.Resolver) (map[string]string, error) {
+	if headers == nil {
+		headers = make(map[string]string, len(remote.Headers))
+	}
+
+	for _, header := range remote.Headers {
+		name := http.CanonicalHeaderKey(header.Name)
+		if _, provided := headers[name]; provided {
+			continue
+		}
+
+		value, err := resolver.Value(header.Name, header.InputWithVariables)
+		if err != nil {
+			return nil, err
+		}
+		if value != "" {
+			headers[name] = value
+		}
+	}
+
+	return headers, nil
+}
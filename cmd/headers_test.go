@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jbovet/mcp-cli/pkg/inputs"
+	"github.com/jbovet/mcp-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := parseHeaders([]string{"x-api-key: ${API_KEY}", "Accept-Language:  en "}, "token")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"X-Api-Key":       "${API_KEY}",
+		"Accept-Language": "en",
+		"Authorization":   "Bearer token",
+	}, headers)
+
+	_, err = parseHeaders([]string{"no-colon"}, "")
+	assert.ErrorContains(t, err, "invalid header")
+
+	_, err = parseHeaders([]string{"Authorization: Basic abc"}, "token")
+	assert.ErrorContains(t, err, "--bearer-token cannot be used together")
+}
+
+func TestPromptRemoteHeaders(t *testing.T) {
+	remote := models.Remote{
+		TransportType: "streamable",
+		URL:           "https://example.com/mcp",
+		Headers: []models.KeyValueInput{
+			{
+				Name: "Authorization",
+				InputWithVariables: models.InputWithVariables{
+					Input: models.Input{Value: "Bearer {api_key}", IsRequired: true},
+					Variables: map[string]models.Input{
+						"api_key": {Description: "API key", IsRequired: true, IsSecret: true},
+					},
+				},
+			},
+			{
+				Name: "X-Region",
+				InputWithVariables: models.InputWithVariables{
+					Input: models.Input{Choices: []string{"us", "eu"}, Default: "us"},
+				},
+			},
+			{
+				Name: "X-Provided",
+				InputWithVariables: models.InputWithVariables{
+					Input: models.Input{IsRequired: true},
+				},
+			},
+		},
+	}
+
+	t.Run("prompts for declared inputs", func(t *testing.T) {
+		var out bytes.Buffer
+		resolver := inputs.NewResolver(newPrompter(strings.NewReader("abc123\n\n"), &out))
+
+		headers, err := promptRemoteHeaders(remote, map[string]string{"X-Provided": "yes"}, resolver)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"Authorization": "Bearer abc123",
+			"X-Region":      "us",
+			"X-Provided":    "yes",
+		}, headers)
+		assert.Contains(t, out.String(), "api_key (API key): ")
+		assert.Contains(t, out.String(), "X-Region [us|eu] [default: us]: ")
+		assert.Equal(t, "Authorization: Bearer ****", resolver.Mask("Authorization: Bearer abc123"))
+	})
+
+	t.Run("missing required value", func(t *testing.T) {
+		resolver := inputs.NewResolver(newPrompter(strings.NewReader(""), &bytes.Buffer{}))
+		_, err := promptRemoteHeaders(remote, nil, resolver)
+		assert.ErrorContains(t, err, "api_key is required")
+	})
+
+	t.Run("invalid choice", func(t *testing.T) {
+		resolver := inputs.NewResolver(newPrompter(strings.NewReader("key\nasia\n"), &bytes.Buffer{}))
+		_, err := promptRemoteHeaders(remote, nil, resolver)
+		assert.ErrorContains(t, err, "must be one of us, eu")
+	})
+}
+
+func TestPrompterRepeated(t *testing.T) {
+	var out bytes.Buffer
+	in := strings.NewReader("a.txt\nb.txt\n\nnext command\n")
+
+	values, err := newPrompter(in, &out).Provide("file", models.Input{}, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.txt", "b.txt"}, values)
+	assert.Equal(t, "file (one per line, empty line to finish): file #2: file #3: ", out.String())
+
+	// Input after the answers is left unread
+	rest, err := io.ReadAll(in)
+	require.NoError(t, err)
+	assert.Equal(t, "next command\n", string(rest))
+}
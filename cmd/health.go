@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/jbovet/mcp-cli/pkg/client"
+	"github.com/jbovet/mcp-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
@@ -35,6 +36,16 @@ func runHealthCommand(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(os.Stderr, "Health check completed successfully\n")
 	}
 
+	if format := selectedOutputFormat(); format.IsStructured() {
+		if err := output.Write(os.Stdout, format, health); err != nil {
+			return err
+		}
+		if health.Status != "ok" {
+			os.Exit(1)
+		}
+		return nil
+	}
+
 	// Display health status
 	fmt.Printf("Service Health Status\n")
 	fmt.Printf("====================\n\n")
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// historySize is the number of commands kept in the history of a server
+const historySize = 1000
+
+// history is the command history of interactive mode. Each server has its
+// own history file, which commands are appended to as they are entered.
+type history struct {
+	path string
+	// entries holds the commands from the oldest to the most recent
+	entries []string
+}
+
+// defaultHistoryDir returns the directory of the history files,
+// $XDG_STATE_HOME/mcp-cli/history or ~/.local/state/mcp-cli/history
+func defaultHistoryDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "mcp-cli", "history"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "state", "mcp-cli", "history"), nil
+}
+
+// historyKey identifies the server selected by the connect flags: the name of
+// a configured or registry server, the URL of a remote server or the command
+// of a local one
+func historyKey() string {
+	switch {
+	case registryServer != "":
+		return "registry:" + registryServer
+	case serverName != "":
+		return "server:" + serverName
+	case connectURL != "":
+		return "url:" + connectURL
+	}
+	return "command:" + strings.Join(append([]string{connectCommand}, connectArgs...), " ")
+}
+
+// historyPath returns the history file of a server in dir
+func historyPath(dir, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(dir, hex.EncodeToString(sum[:16])+".history")
+}
+
+// openHistory loads the history of the server selected by the connect flags.
+// Without a usable history file, the history is only kept for the session.
+func openHistory() *history {
+	dir, err := defaultHistoryDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: command history is not saved: %v\n", err)
+		return &history{}
+	}
+	h, err := loadHistory(historyPath(dir, historyKey()))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: command history is not saved: %v\n", err)
+		return &history{}
+	}
+	return h
+}
+
+// loadHistory reads the history file at path. A missing file is an empty
+// history, and a file grown beyond historySize commands is truncated.
+func loadHistory(path string) (*history, error) {
+	h := &history{path: path}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	if len(h.entries) > historySize {
+		h.entries = h.entries[len(h.entries)-historySize:]
+		data := strings.Join(h.entries, "\n") + "\n"
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write history: %w", err)
+		}
+	}
+	return h, nil
+}
+
+// Add appends a command to the history and to its file. Blank commands,
+// repetitions of the last command and commands starting with a space, like
+// in shells, are not recorded.
+func (h *history) Add(entry string) error {
+	if strings.HasPrefix(entry, " ") {
+		return nil
+	}
+	entry = strings.TrimSpace(entry)
+	if entry == "" || strings.Contains(entry, "\n") {
+		return nil
+	}
+	if len(h.entries) > 0 && h.entries[len(h.entries)-1] == entry {
+		return nil
+	}
+
+	h.entries = append(h.entries, entry)
+	if len(h.entries) > historySize {
+		h.entries = h.entries[1:]
+	}
+	if h.path == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+	file, err := os.OpenFile(h.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	// The commands hold arguments in plain text: a file created with wider
+	// permissions is restricted to its owner
+	if err := restrictHistory(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if _, err := file.WriteString(entry + "\n"); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return file.Close()
+}
+
+// restrictHistory makes a history file readable by its owner only
+func restrictHistory(file *os.File) error {
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	if info.Mode().Perm()&0o077 == 0 {
+		return nil
+	}
+	if err := file.Chmod(0o600); err != nil {
+		return fmt.Errorf("failed to restrict history permissions: %w", err)
+	}
+	return nil
+}
+
+// Len returns the number of commands in the history
+func (h *history) Len() int {
+	return len(h.entries)
+}
+
+// At returns a command of the history, 0 being the most recent one
+func (h *history) At(i int) string {
+	return h.entries[len(h.entries)-1-i]
+}
+
+// search returns the index of the most recent command from index from
+// containing query, or -1
+func (h *history) search(query string, from int) int {
+	for i := max(from, 0); i < h.Len(); i++ {
+		if strings.Contains(h.At(i), query) {
+			return i
+		}
+	}
+	return -1
+}
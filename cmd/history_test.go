@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history", "server.history")
+
+	h, err := loadHistory(path)
+	require.NoError(t, err)
+	assert.Equal(t, 0, h.Len())
+
+	for _, entry := range []string{"tools", "call echo hi", "call echo hi", "  ", " call login password=secret", "read file:///tmp/a"} {
+		require.NoError(t, h.Add(entry))
+	}
+	assert.Equal(t, 3, h.Len())
+	assert.Equal(t, "read file:///tmp/a", h.At(0))
+	assert.Equal(t, "tools", h.At(2))
+	assert.Equal(t, 1, h.search("echo", 0))
+	assert.Equal(t, 2, h.search("o", 2))
+	assert.Equal(t, -1, h.search("prompt", 0))
+
+	reloaded, err := loadHistory(path)
+	require.NoError(t, err)
+	assert.Equal(t, h.entries, reloaded.entries)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestHistoryPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions are not enforced on Windows")
+	}
+	path := filepath.Join(t.TempDir(), "server.history")
+	require.NoError(t, os.WriteFile(path, []byte("tools\n"), 0o644))
+	// The umask may have narrowed the permissions of the file
+	require.NoError(t, os.Chmod(path, 0o644))
+
+	h, err := loadHistory(path)
+	require.NoError(t, err)
+	require.NoError(t, h.Add("call echo hi"))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestLoadHistoryTruncates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.history")
+	var lines []string
+	for i := range historySize + 10 {
+		lines = append(lines, fmt.Sprintf("call echo %d", i))
+	}
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+
+	h, err := loadHistory(path)
+	require.NoError(t, err)
+	assert.Equal(t, historySize, h.Len())
+	assert.Equal(t, "call echo 10", h.At(historySize-1))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, historySize, strings.Count(string(data), "\n"))
+}
+
+func TestHistoryPath(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/state")
+	dir, err := defaultHistoryDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/state", "mcp-cli", "history"), dir)
+
+	path := historyPath(dir, "server:filesystem")
+	assert.Equal(t, path, historyPath(dir, "server:filesystem"))
+	assert.NotEqual(t, path, historyPath(dir, "server:github"))
+	assert.Equal(t, ".history", filepath.Ext(path))
+}
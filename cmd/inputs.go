@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jbovet/mcp-cli/pkg/inputs"
+	"github.com/jbovet/mcp-cli/pkg/models"
+	"golang.org/x/term"
+)
+
+// inputValues are the registry input values given with --input name=value
+var inputValues []string
+
+// newInputResolver creates a resolver for registry inputs taking the values
+// given with --input and asking for the others on stdin
+func newInputResolver() (*inputs.Resolver, error) {
+	values, err := inputs.ParseValues(inputValues)
+	if err != nil {
+		return nil, err
+	}
+	return inputs.NewResolver(inputs.Providers{values, newPrompter(os.Stdin, os.Stderr)}), nil
+}
+
+// prompter asks the user for the values of registry inputs. Answers are read
+// without buffering, so input following them is left for the caller.
+type prompter struct {
+	in  io.Reader
+	out io.Writer
+
+	// terminal is the file descriptor of in when it is a terminal, so secrets
+	// can be read without echo, and -1 otherwise
+	terminal int
+}
+
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	p := &prompter{in: in, out: out, terminal: -1}
+	if f, ok := in.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		p.terminal = int(f.Fd())
+	}
+	return p
+}
+
+// Provide implements inputs.Provider. Repeated inputs are asked for until
+// an empty answer is given.
+func (p *prompter) Provide(name string, input models.Input, repeated bool) ([]string, error) {
+	label := name
+	if input.Description != "" {
+		label += " (" + input.Description + ")"
+	}
+	if len(input.Choices) > 0 {
+		label += " [" + strings.Join(input.Choices, "|") + "]"
+	}
+	if input.Default != "" && !input.IsSecret {
+		label += " [default: " + input.Default + "]"
+	}
+	if repeated {
+		label += " (one per line, empty line to finish)"
+	}
+
+	var values []string
+	for {
+		prompt := label
+		if len(values) > 0 {
+			prompt = fmt.Sprintf("%s #%d", name, len(values)+1)
+		}
+		if _, err := fmt.Fprintf(p.out, "%s: ", prompt); err != nil {
+			return nil, err
+		}
+
+		line, err := p.readLine(input.IsSecret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			return values, nil
+		}
+
+		values = append(values, answer)
+		if !repeated {
+			return values, nil
+		}
+	}
+}
+
+// readLine reads an answer. Secrets typed on a terminal are not echoed. The
+// end of the input reads as an empty answer.
+func (p *prompter) readLine(secret bool) (string, error) {
+	line, err := p.readAnswer(secret)
+	if err == io.EOF {
+		return line, nil
+	}
+	return line, err
+}
+
+// readAnswer reads an answer like readLine, but returns io.EOF when the
+// input ends before a line is read
+func (p *prompter) readAnswer(secret bool) (string, error) {
+	if secret && p.terminal >= 0 {
+		line, err := term.ReadPassword(p.terminal)
+		_, _ = fmt.Fprintln(p.out)
+		return string(line), err
+	}
+
+	var line []byte
+	b := make([]byte, 1)
+	for {
+		n, err := p.in.Read(b)
+		if n > 0 {
+			if b[0] == '\n' {
+				break
+			}
+			line = append(line, b[0])
+		}
+		if err == io.EOF {
+			if len(line) == 0 {
+				return "", io.EOF
+			}
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return string(line), nil
+}
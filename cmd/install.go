@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/jbovet/mcp-cli/pkg/client"
+	"github.com/jbovet/mcp-cli/pkg/config"
+	"github.com/jbovet/mcp-cli/pkg/inputs"
+	"github.com/jbovet/mcp-cli/pkg/models"
+	"github.com/jbovet/mcp-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Flags for install command
+	installName    string
+	installPackage int
+	installForce   bool
+	installDryRun  bool
+)
+
+// installCmd represents the install command
+var installCmd = &cobra.Command{
+	Use:   "install <registry-name>",
+	Short: "Install a registry server as a named server",
+	Long: `Look up the latest version of a server in the MCP Registry and add a
+configuration entry running one of its packages, so it can be used with
+"mcp-cli connect <name>".
+
+Packages are run with the runtime of their registry, unless the package
+declares a runtime hint:
+- npm:    npx
+- pypi:   uvx
+- docker: docker run
+- nuget:  dnx
+
+The inputs declared by the package (arguments and environment variables) can
+be given with --input name=value, using the argument name without leading
+dashes, the value hint of positional arguments or the variable name. The
+others are asked for. Defaults are offered, values are checked against the
+input format (number, boolean, file_path) and allowed choices, repeated
+arguments accept several values and secrets are read without echo and masked
+in the output. Secret values are stored in the configuration file, which is
+only readable by the current user. The other entries of the file are kept
+with their comments and formatting.`,
+	Example: `  # Install a server under the last segment of its name
+  mcp-cli install io.github.modelcontextprotocol/filesystem
+
+  # Choose the second package listed for the server and the entry name
+  mcp-cli install io.github.owner/server-name --package 2 --name my-server
+
+  # Give input values on the command line instead of answering prompts
+  mcp-cli install io.github.owner/server-name --input port=8080 --input API_KEY='${API_KEY}'
+
+  # Show the resulting entry without writing the configuration file
+  mcp-cli install io.github.owner/server-name --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInstall,
+}
+
+// installResult is the structured output of the install command
+type installResult struct {
+	Name     string        `json:"name"`
+	Server   string        `json:"server"`
+	Version  string        `json:"version"`
+	Registry string        `json:"registry"`
+	Package  string        `json:"package"`
+	Entry    config.Server `json:"entry"`
+	Path     string        `json:"path"`
+	DryRun   bool          `json:"dry_run,omitempty"`
+}
+
+func runInstall(cmd *cobra.Command, args []string) error {
+	registryName := args[0]
+	cmd.SilenceUsage = true
+
+	file, err := config.Load(configPath)
+	if err != nil {
+		return err
+	}
+
+	name := installName
+	if name == "" {
+		name = defaultEntryName(registryName)
+	}
+	if _, exists := file.Servers[name]; exists && !installForce {
+		return fmt.Errorf("server %q is already configured in %s (use --force to overwrite or --name to choose another name)", name, file.Path())
+	}
+
+	if verbose {
+		fmt.Fprintf(os.Stderr, "Looking up server by name: %s\n", registryName)
+	}
+	server, err := client.NewClient(baseURL).GetServerByName(registryName)
+	if err != nil {
+		return fmt.Errorf("failed to fetch server: %w", err)
+	}
+
+	pkg, err := selectPackage(server, installPackage)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Installing %s %s from %s package %s\n", server.Name, server.VersionDetail.Version, pkg.RegistryName, pkg.Name)
+
+	resolver, err := newInputResolver()
+	if err != nil {
+		return err
+	}
+	entry, err := packageServer(pkg, resolver)
+	if err != nil {
+		return err
+	}
+
+	existing, exists := file.Servers[name]
+	if !installDryRun && (!exists || !reflect.DeepEqual(existing, entry)) {
+		file.Servers[name] = entry
+		if err := file.Save(); err != nil {
+			return err
+		}
+	}
+
+	if format := selectedOutputFormat(); format.IsStructured() {
+		return output.Write(os.Stdout, format, installResult{
+			Name:     name,
+			Server:   server.Name,
+			Version:  server.VersionDetail.Version,
+			Registry: pkg.RegistryName,
+			Package:  pkg.Name,
+			Entry:    maskServer(entry, resolver),
+			Path:     file.Path(),
+			DryRun:   installDryRun,
+		})
+	}
+
+	commandLine := append([]string{entry.Command}, entry.Args...)
+	fmt.Printf("Command:     %s\n", resolver.Mask(strings.Join(commandLine, " ")))
+	for _, key := range sortedKeys(entry.Env) {
+		fmt.Printf("Environment: %s=%s\n", key, resolver.Mask(entry.Env[key]))
+	}
+	if installDryRun {
+		fmt.Printf("\nDry run: %q was not added to %s\n", name, file.Path())
+		return nil
+	}
+	fmt.Printf("\nInstalled %q in %s\n", name, file.Path())
+	fmt.Printf("Connect with: mcp-cli connect %s\n", name)
+	return nil
+}
+
+// defaultEntryName derives a configuration entry name from a registry name
+// such as io.github.owner/server-name
+func defaultEntryName(registryName string) string {
+	return strings.ToLower(path.Base(registryName))
+}
+
+// selectPackage returns the package with the given 1-based index, or the
+// first package with a supported registry when index is 0
+func selectPackage(server *client.ServerDetail, index int) (models.Package, error) {
+	if len(server.Packages) == 0 {
+		return models.Package{}, fmt.Errorf("server %s has no packages", server.Name)
+	}
+
+	if index != 0 {
+		if index < 1 || index > len(server.Packages) {
+			return models.Package{}, fmt.Errorf("invalid package %d: server %s has %d package(s)", index, server.Name, len(server.Packages))
+		}
+		return server.Packages[index-1], nil
+	}
+
+	for _, pkg := range server.Packages {
+		if _, err := inputs.Runtime(pkg); err == nil {
+			return pkg, nil
+		}
+	}
+	return models.Package{}, fmt.Errorf("server %s has no package from a supported registry (%s)", server.Name, strings.Join(inputs.Registries(), ", "))
+}
+
+// packageServer builds the configuration entry running a registry package,
+// resolving the values of its inputs
+func packageServer(pkg models.Package, resolver *inputs.Resolver) (config.Server, error) {
+	command, err := resolver.Package(pkg)
+	if err != nil {
+		return config.Server{}, err
+	}
+	return config.Server{Type: "stdio", Command: command.Command, Args: command.Args, Env: command.Env}, nil
+}
+
+// maskServer hides the secrets resolved for a configuration entry
+func maskServer(server config.Server, resolver *inputs.Resolver) config.Server {
+	masked := server
+	masked.Args = resolver.MaskArgs(server.Args)
+	masked.Env = resolver.MaskMap(server.Env)
+	masked.Headers = resolver.MaskMap(server.Headers)
+	return masked
+}
+
+func init() {
+	rootCmd.AddCommand(installCmd)
+	installCmd.Flags().StringVar(&installName, "name", "", "Name of the configuration entry (default: last segment of the registry name)")
+	installCmd.Flags().IntVar(&installPackage, "package", 0, "Package to install, as numbered by \"get server\" (default: first supported package)")
+	installCmd.Flags().BoolVar(&installForce, "force", false, "Overwrite a configured server with the same name")
+	installCmd.Flags().StringArrayVar(&inputValues, "input", nil, "Value of a package input as name=value (can be repeated)")
+	installCmd.Flags().BoolVar(&installDryRun, "dry-run", false, "Show the entry without writing the configuration file")
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jbovet/mcp-cli/pkg/client"
+	"github.com/jbovet/mcp-cli/pkg/config"
+	"github.com/jbovet/mcp-cli/pkg/inputs"
+	"github.com/jbovet/mcp-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageServer(t *testing.T) {
+	pkg := models.Package{
+		RegistryName: "docker",
+		Name:         "ghcr.io/github/github-mcp-server",
+		EnvironmentVariables: []models.KeyValueInput{
+			{Name: "GITHUB_TOKEN", InputWithVariables: models.InputWithVariables{Input: models.Input{IsRequired: true, IsSecret: true}}},
+		},
+	}
+	resolver := inputs.NewResolver(newPrompter(strings.NewReader("ghp_secret\n"), &bytes.Buffer{}))
+
+	server, err := packageServer(pkg, resolver)
+	require.NoError(t, err)
+	assert.Equal(t, config.Server{
+		Type:    "stdio",
+		Command: "docker",
+		Args:    []string{"run", "-i", "--rm", "-e", "GITHUB_TOKEN", "ghcr.io/github/github-mcp-server"},
+		Env:     map[string]string{"GITHUB_TOKEN": "ghp_secret"},
+	}, server)
+	assert.Equal(t, map[string]string{"GITHUB_TOKEN": inputs.Mask}, maskServer(server, resolver).Env)
+}
+
+func TestSelectPackage(t *testing.T) {
+	server := &client.ServerDetail{
+		Server: models.Server{Name: "io.github.owner/server"},
+		Packages: []models.Package{
+			{RegistryName: "cargo", Name: "a"},
+			{RegistryName: "pypi", Name: "b"},
+		},
+	}
+
+	pkg, err := selectPackage(server, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "b", pkg.Name)
+
+	pkg, err = selectPackage(server, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "a", pkg.Name)
+
+	_, err = selectPackage(server, 3)
+	assert.ErrorContains(t, err, "has 2 package(s)")
+
+	_, err = selectPackage(&client.ServerDetail{Server: models.Server{Name: "remote-only"}}, 0)
+	assert.ErrorContains(t, err, "has no packages")
+}
+
+func TestDefaultEntryName(t *testing.T) {
+	assert.Equal(t, "server-name", defaultEntryName("io.github.owner/Server-Name"))
+	assert.Equal(t, "redis", defaultEntryName("redis"))
+}
@@ -0,0 +1,413 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+const (
+	// replPrompt is the prompt of interactive mode
+	replPrompt = "> "
+
+	// continuationPrompt is shown while braces or brackets are open
+	continuationPrompt = "... "
+)
+
+// Keys handled by the completion callback of the terminal. The terminal
+// handles Backspace and the other control keys itself, so while searching
+// they are translated by searchKeys into keys passed to the callback.
+const (
+	keyCtrlG = 0x07
+	keyCtrlR = 0x12
+
+	// keySearchEnd ends the search before the key following it is handled
+	keySearchEnd = 0x1e
+	// keySearchBackspace deletes the last character of the search
+	keySearchBackspace = 0x1f
+)
+
+// newLineReader edits the commands of interactive mode on the terminal, with
+// Tab passed to complete and commands recorded in h. Input which is not a
+// terminal is run as a script instead.
+func newLineReader(complete func(line string) completion, h *history) *terminalReader {
+	return newTerminalReader(int(os.Stdin.Fd()), os.Stdin, os.Stdout, complete, h)
+}
+
+// readCommand reads the lines of a command until its braces and brackets are
+// closed. The lines are joined with spaces, so the command fits on one line
+// of the history. The input may only end between commands.
+func readCommand(readLine func(prompt string) (string, error)) (string, error) {
+	var lines []string
+	prompt := replPrompt
+	for {
+		line, err := readLine(prompt)
+		if errors.Is(err, io.EOF) && len(lines) > 0 {
+			return "", fmt.Errorf("braces or brackets left open: %w", io.ErrUnexpectedEOF)
+		}
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, line)
+
+		command := strings.Join(lines, " ")
+		if !openBrackets(command) {
+			return command, nil
+		}
+		prompt = continuationPrompt
+	}
+}
+
+// openBrackets reports whether a command line has braces or brackets left
+// open, outside of quotes as split by splitCommandLine
+func openBrackets(line string) bool {
+	var (
+		depth   int
+		quote   rune
+		escaped bool
+	)
+	for _, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && (quote != '\'' || depth > 0):
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '{' || r == '[':
+			depth++
+		case (r == '}' || r == ']') && depth > 0:
+			depth--
+		}
+	}
+	return depth > 0
+}
+
+// terminalReader edits lines on the terminal, with the history of the server
+// and completion. Commands continue on the next lines while braces or
+// brackets are open, so JSON can be pasted. The terminal is only in raw mode
+// while a command is read, so the commands of the session, and the forms and
+// Ctrl+C handling they use, see a normal terminal.
+type terminalReader struct {
+	fd       int
+	terminal *term.Terminal
+	complete func(line string) completion
+	history  *history
+
+	// prompt is the prompt of the line being read
+	prompt string
+	// search is the reverse search in progress, started with Ctrl+R
+	search *historySearch
+}
+
+// historySearch is the state of a reverse search of the history
+type historySearch struct {
+	query string
+	// index is the history entry matching query, -1 before any match
+	index int
+	// original is the line edited when the search started
+	original string
+	failed   bool
+}
+
+func newTerminalReader(fd int, in io.Reader, out io.Writer, complete func(line string) completion, h *history) *terminalReader {
+	r := &terminalReader{fd: fd, complete: complete, history: h, prompt: replPrompt}
+	r.terminal = term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{&searchKeys{in: in, reader: r}, out}, replPrompt)
+	r.terminal.AutoCompleteCallback = r.autoComplete
+	r.terminal.History = terminalHistory{h}
+	return r
+}
+
+// ReadLine reads a command. It returns io.EOF when the input ends.
+func (r *terminalReader) ReadLine() (string, error) {
+	// Waits for the prompt answering a request of the server to end, and
+	// refuses new ones until the command is read
+	replOutput.setTerminal(r.terminal)
+	defer replOutput.setTerminal(nil)
+
+	state, err := term.MakeRaw(r.fd)
+	if err != nil {
+		return "", fmt.Errorf("failed to set up the terminal: %w", err)
+	}
+	defer func() { _ = term.Restore(r.fd, state) }()
+
+	if width, height, err := term.GetSize(r.fd); err == nil && width > 0 {
+		_ = r.terminal.SetSize(width, height)
+	}
+	return r.readCommand()
+}
+
+// readCommand reads a command and records it in the history. Ctrl+C and
+// Ctrl+D on an empty line both end the input.
+func (r *terminalReader) readCommand() (string, error) {
+	command, err := readCommand(func(prompt string) (string, error) {
+		r.prompt, r.search = prompt, nil
+		r.terminal.SetPrompt(prompt)
+		return r.terminal.ReadLine()
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := r.history.Add(command); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: command history is no longer saved: %v\n", err)
+		r.history.path = ""
+	}
+	return command, nil
+}
+
+// autoComplete handles the keys the terminal does not: Tab completes the line
+// before the cursor, and Ctrl+R searches the history. When completion finds
+// several candidates and none of them can be inserted, they are listed above
+// the prompt.
+func (r *terminalReader) autoComplete(line string, pos int, key rune) (string, int, bool) {
+	if r.search != nil {
+		return r.searchKey(line, key)
+	}
+
+	switch key {
+	case keyCtrlR:
+		r.search = &historySearch{index: -1, original: line}
+		r.showSearch()
+		return line, pos, true
+	case '\t':
+		if r.complete == nil {
+			return "", 0, false
+		}
+		before, after := line[:pos], line[pos:]
+		result := r.complete(before)
+		if len(result.candidates) > 1 && result.line == before {
+			fmt.Fprintln(r.terminal, strings.Join(result.candidates, "  "))
+		}
+		return result.line + after, len(result.line), true
+	}
+	return "", 0, false
+}
+
+// searchKey handles a key during a reverse search. Typed characters extend
+// the query, Ctrl+R moves to the next older match and Ctrl+G restores the
+// original line. Other control keys end the search on the matched line, and
+// are then handled as usual.
+func (r *terminalReader) searchKey(line string, key rune) (string, int, bool) {
+	s := r.search
+	switch {
+	case key == keyCtrlG:
+		r.endSearch()
+		return s.original, len(s.original), true
+	case key == keySearchEnd:
+		r.endSearch()
+		return line, len(line), true
+	case key == keyCtrlR:
+		r.find(s.index + 1)
+	case key == keySearchBackspace:
+		if s.query == "" {
+			return line, len(line), true
+		}
+		_, size := utf8.DecodeLastRuneInString(s.query)
+		s.query = s.query[:len(s.query)-size]
+		r.find(0)
+	case unicode.IsPrint(key):
+		s.query += string(key)
+		r.find(s.index)
+	default:
+		return line, len(line), true
+	}
+
+	r.showSearch()
+	if s.index < 0 {
+		return s.original, len(s.original), true
+	}
+	match := r.history.At(s.index)
+	return match, max(strings.Index(match, s.query), 0), true
+}
+
+// find moves the search to the most recent match of the query from the
+// history entry at index from. The current match is kept when there is none.
+func (r *terminalReader) find(from int) {
+	s := r.search
+	if s.query == "" {
+		s.index, s.failed = -1, false
+		return
+	}
+	if i := r.history.search(s.query, from); i >= 0 {
+		s.index, s.failed = i, false
+		return
+	}
+	s.failed = true
+}
+
+// showSearch shows the query of the search in place of the prompt
+func (r *terminalReader) showSearch() {
+	prompt := fmt.Sprintf("(reverse-i-search)`%s': ", r.search.query)
+	if r.search.failed {
+		prompt = "(failed " + prompt[1:]
+	}
+	r.setPrompt(prompt)
+}
+
+func (r *terminalReader) endSearch() {
+	r.search = nil
+	r.setPrompt(r.prompt)
+}
+
+// setPrompt replaces the prompt of the line being edited
+func (r *terminalReader) setPrompt(prompt string) {
+	r.terminal.SetPrompt(prompt)
+	// Writing nothing redraws the prompt and the line
+	_, _ = r.terminal.Write(nil)
+}
+
+// searchKeys translates the keys typed during a reverse search which the
+// terminal would handle itself, so they reach the completion callback
+type searchKeys struct {
+	in      io.Reader
+	reader  *terminalReader
+	pending []byte
+}
+
+func (k *searchKeys) Read(p []byte) (int, error) {
+	if len(k.pending) == 0 {
+		buf := make([]byte, len(p))
+		n, err := k.in.Read(buf)
+		if n == 0 {
+			return 0, err
+		}
+		k.pending = buf[:n]
+		if k.reader.search != nil {
+			k.pending = translateSearchKeys(k.pending)
+		}
+	}
+	n := copy(p, k.pending)
+	k.pending = k.pending[n:]
+	return n, nil
+}
+
+// translateSearchKeys replaces Backspace with keySearchBackspace, and puts
+// keySearchEnd before the first control key other than Ctrl+R and Ctrl+G
+func translateSearchKeys(input []byte) []byte {
+	out := make([]byte, 0, len(input)+1)
+	for i, c := range input {
+		switch {
+		case c == 0x7f || c == 0x08:
+			out = append(out, keySearchBackspace)
+		case c == keyCtrlR || c == keyCtrlG:
+			out = append(out, c)
+		case c < 0x20:
+			out = append(out, keySearchEnd)
+			return append(out, input[i:]...)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// terminalHistory gives the terminal access to the history for the Up and
+// Down keys. Commands are added by terminalReader once complete, rather than
+// line by line by the terminal.
+type terminalHistory struct {
+	history *history
+}
+
+func (h terminalHistory) Add(string) {}
+
+func (h terminalHistory) Len() int {
+	return h.history.Len()
+}
+
+func (h terminalHistory) At(i int) string {
+	return h.history.At(i)
+}
+
+// sessionOutput writes the messages arriving asynchronously during an
+// interactive session, such as notifications. While a line is edited, they
+// are written above it and the prompt is redrawn.
+//
+// It also keeps stdin to one reader: the prompts answering the requests of
+// the server are refused while a command is read, and a command is only read
+// once they end.
+type sessionOutput struct {
+	mu       sync.Mutex
+	terminal *term.Terminal
+	// prompting is set while a request of the server is answered on stdin
+	prompting bool
+	// idle is signalled when a prompt ends
+	idle *sync.Cond
+}
+
+// replOutput is the output of the notifications of interactive mode
+var replOutput = newSessionOutput()
+
+// errCommandPrompt refuses a prompt while interactive mode waits for a
+// command
+var errCommandPrompt = errors.New("the interactive prompt is waiting for a command, requests can only be answered while a command runs")
+
+func newSessionOutput() *sessionOutput {
+	o := &sessionOutput{}
+	o.idle = sync.NewCond(&o.mu)
+	return o
+}
+
+// setTerminal sets the terminal a command is read on, once no prompt is
+// shown, or clears it when nil
+func (o *sessionOutput) setTerminal(terminal *term.Terminal) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for terminal != nil && o.prompting {
+		o.idle.Wait()
+	}
+	o.terminal = terminal
+}
+
+// startPrompt reserves stdin for a prompt, failing with errCommandPrompt
+// while a command is read. endPrompt must be called once it is answered.
+func (o *sessionOutput) startPrompt() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.terminal != nil {
+		return errCommandPrompt
+	}
+	o.prompting = true
+	return nil
+}
+
+func (o *sessionOutput) endPrompt() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.prompting = false
+	o.idle.Broadcast()
+}
+
+func (o *sessionOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.terminal != nil {
+		return o.terminal.Write(p)
+	}
+	return os.Stdout.Write(p)
+}
+
+// warn writes a warning above the line being edited, or to stderr
+func (o *sessionOutput) warn(warning string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.terminal != nil {
+		fmt.Fprintf(o.terminal, "Warning: %s\n", warning)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+}
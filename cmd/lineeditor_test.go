@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/term"
+)
+
+func TestOpenBrackets(t *testing.T) {
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{line: "tools", want: false},
+		{line: "call create {", want: true},
+		{line: `call create {"tags": [`, want: true},
+		{line: `call create {"tags": ["a"]}`, want: false},
+		{line: `call create {"name": "{"`, want: true},
+		{line: `call create {"name": "}"`, want: true},
+		{line: `call echo '{'`, want: false},
+		{line: `call echo \{`, want: false},
+		{line: "call echo ]", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			assert.Equal(t, tt.want, openBrackets(tt.line))
+		})
+	}
+}
+
+func TestReadCommand(t *testing.T) {
+	reader := func(lines ...string) func(prompt string) (string, error) {
+		return func(prompt string) (string, error) {
+			if len(lines) == 0 {
+				return "", io.EOF
+			}
+			line := lines[0]
+			lines = lines[1:]
+			return line, nil
+		}
+	}
+
+	readLine := reader("tools", "call create {", `  "tags": ["a",`, `    "b"]`, "}")
+	command, err := readCommand(readLine)
+	require.NoError(t, err)
+	assert.Equal(t, "tools", command)
+
+	command, err = readCommand(readLine)
+	require.NoError(t, err)
+	assert.Equal(t, `call create {   "tags": ["a",     "b"] }`, command)
+
+	_, err = readCommand(readLine)
+	assert.Equal(t, io.EOF, err)
+
+	_, err = readCommand(reader("call create {"))
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestTerminalReader(t *testing.T) {
+	readCommands := func(t *testing.T, h *history, input string) []string {
+		complete := func(line string) completion {
+			return completeWord(line, line, replCommands, " ")
+		}
+		// Keys are read one at a time, as typed
+		reader := newTerminalReader(-1, iotest.OneByteReader(strings.NewReader(input)), io.Discard, complete, h)
+
+		var commands []string
+		for {
+			command, err := reader.readCommand()
+			if err == io.EOF {
+				return commands
+			}
+			require.NoError(t, err)
+			commands = append(commands, command)
+		}
+	}
+
+	newHistory := func(entries ...string) *history {
+		return &history{entries: entries}
+	}
+
+	t.Run("history", func(t *testing.T) {
+		h := newHistory("tools")
+		commands := readCommands(t, h, "prompts\r\x1b[A\x1b[A\r")
+		assert.Equal(t, []string{"prompts", "tools"}, commands)
+		assert.Equal(t, []string{"tools", "prompts", "tools"}, h.entries)
+	})
+
+	t.Run("multi-line", func(t *testing.T) {
+		h := newHistory()
+		commands := readCommands(t, h, "call create {\r\"n\": 1}\r")
+		assert.Equal(t, []string{`call create { "n": 1}`}, commands)
+		assert.Equal(t, commands, h.entries)
+	})
+
+	t.Run("completion", func(t *testing.T) {
+		commands := readCommands(t, newHistory(), "too\tx\r")
+		assert.Equal(t, []string{"tools x"}, commands)
+	})
+
+	t.Run("reverse search", func(t *testing.T) {
+		h := newHistory("call echo one", "tools", "call echo two")
+		commands := readCommands(t, h, "\x12echo\r")
+		assert.Equal(t, []string{"call echo two"}, commands)
+	})
+
+	t.Run("older match", func(t *testing.T) {
+		h := newHistory("call echo one", "tools", "call echo two")
+		commands := readCommands(t, h, "\x12echo\x12\x12\r")
+		assert.Equal(t, []string{"call echo one"}, commands)
+	})
+
+	t.Run("backspace", func(t *testing.T) {
+		h := newHistory("tools", "call echo two")
+		commands := readCommands(t, h, "\x12tz\x7f\r")
+		assert.Equal(t, []string{"call echo two"}, commands)
+	})
+
+	t.Run("edit match", func(t *testing.T) {
+		h := newHistory("call echo one", "tools")
+		commands := readCommands(t, h, "\x12one\x1b[F!\r")
+		assert.Equal(t, []string{"call echo one!"}, commands)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		h := newHistory("tools")
+		commands := readCommands(t, h, "call\x12too\x07 x\r")
+		assert.Equal(t, []string{"call x"}, commands)
+	})
+}
+
+func TestSessionOutputPrompts(t *testing.T) {
+	o := newSessionOutput()
+	terminal := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{strings.NewReader(""), io.Discard}, replPrompt)
+
+	// Prompts are refused while a command is read
+	o.setTerminal(terminal)
+	assert.ErrorIs(t, o.startPrompt(), errCommandPrompt)
+	o.setTerminal(nil)
+
+	// A command is only read once the prompt ends
+	require.NoError(t, o.startPrompt())
+	reading := make(chan struct{})
+	go func() {
+		o.setTerminal(terminal)
+		close(reading)
+	}()
+	select {
+	case <-reading:
+		t.Fatal("the command was read during the prompt")
+	case <-time.After(50 * time.Millisecond):
+	}
+	o.endPrompt()
+	<-reading
+	assert.ErrorIs(t, o.startPrompt(), errCommandPrompt)
+}
+
+func TestSessionOutputWarn(t *testing.T) {
+	var out strings.Builder
+	o := newSessionOutput()
+	o.setTerminal(term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{strings.NewReader(""), &out}, replPrompt))
+
+	o.warn("listing of tools stopped after 2 pages")
+	assert.Contains(t, out.String(), "Warning: listing of tools stopped after 2 pages\r\n")
+}
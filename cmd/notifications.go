@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+	"github.com/jbovet/mcp-cli/pkg/output"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+var (
+	// Flags for following server notifications
+	followMode      bool
+	connectLogLevel string
+
+	// notificationHandler is registered by connectToServer before connecting
+	notificationHandler adapter.NotificationHandler
+)
+
+// notificationPrinter writes server notifications as they arrive, one line
+// each in text mode, or as JSON lines or YAML documents
+type notificationPrinter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	format output.Format
+	now    func() time.Time
+}
+
+func newNotificationPrinter(w io.Writer, format output.Format) *notificationPrinter {
+	return &notificationPrinter{w: w, format: format, now: time.Now}
+}
+
+// handle implements adapter.NotificationHandler
+func (p *notificationPrinter) handle(notification mcp.JSONRPCNotification) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Decoded notifications always carry a _meta object
+	message := notification.Notification
+	if len(message.Params.Meta) == 0 {
+		message.Params.Meta = nil
+	}
+
+	var err error
+	switch p.format {
+	case output.FormatJSON:
+		var data []byte
+		if data, err = json.Marshal(message); err == nil {
+			_, err = fmt.Fprintf(p.w, "%s\n", data)
+		}
+	case output.FormatYAML:
+		if _, err = fmt.Fprintln(p.w, "---"); err == nil {
+			err = output.Write(p.w, p.format, message)
+		}
+	default:
+		_, err = fmt.Fprintf(p.w, "%s %s\n", p.now().Format("15:04:05"), formatNotification(notification))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to print notification: %v\n", err)
+	}
+}
+
+// formatNotification describes a notification on a single line
+func formatNotification(notification mcp.JSONRPCNotification) string {
+	params := notification.Params.AdditionalFields
+	switch notification.Method {
+	case "notifications/message":
+		line := fmt.Sprintf("[%s]", stringParam(params, "level"))
+		if logger := stringParam(params, "logger"); logger != "" {
+			line += " " + logger + ":"
+		}
+		return line + " " + formatValue(params["data"])
+	case mcp.MethodNotificationToolsListChanged:
+		return "Tool list changed"
+	case mcp.MethodNotificationResourcesListChanged:
+		return "Resource list changed"
+	case mcp.MethodNotificationPromptsListChanged:
+		return "Prompt list changed"
+	case mcp.MethodNotificationResourceUpdated:
+		return "Resource updated: " + stringParam(params, "uri")
+	case "notifications/progress":
+		line := "Progress " + formatValue(params["progress"])
+		if total, ok := params["total"]; ok {
+			line += "/" + formatValue(total)
+		}
+		if message := stringParam(params, "message"); message != "" {
+			line += ": " + message
+		}
+		return line
+	case "notifications/cancelled":
+		line := "Request " + formatValue(params["requestId"]) + " cancelled"
+		if reason := stringParam(params, "reason"); reason != "" {
+			line += ": " + reason
+		}
+		return line
+	default:
+		if len(params) == 0 {
+			return notification.Method
+		}
+		keys := make([]string, 0, len(params))
+		for key := range params {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fields := make([]string, len(keys))
+		for i, key := range keys {
+			fields[i] = key + "=" + formatValue(params[key])
+		}
+		return notification.Method + " " + strings.Join(fields, " ")
+	}
+}
+
+func stringParam(params map[string]any, name string) string {
+	if value, ok := params[name].(string); ok {
+		return value
+	}
+	return ""
+}
+
+// formatValue formats a notification parameter: strings as-is, other values
+// as JSON
+func formatValue(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+// setLogLevel applies --log-level to a connected server. Servers without
+// logging support reject the request, which is reported as a warning.
+func setLogLevel(ctx context.Context, serverAdapter adapter.ServerAdapter, level mcp.LoggingLevel) {
+	if err := serverAdapter.SetLogLevel(ctx, level); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// followNotifications waits until interrupted while notifications are
+// printed by the handler registered when connecting. It fails when the
+// connection to the server is lost.
+func followNotifications(serverAdapter adapter.ServerAdapter) error {
+	fmt.Fprintln(os.Stderr, "Following server notifications (press Ctrl+C to stop)...")
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-serverAdapter.Done():
+		return fmt.Errorf("connection to the server lost: %w", serverAdapter.Err())
+	}
+}
+
+func setLogLevelInteractive(ctx context.Context, serverAdapter adapter.ServerAdapter, name string) error {
+	level, err := adapter.ParseLoggingLevel(name)
+	if err != nil {
+		return err
+	}
+	if err := serverAdapter.SetLogLevel(ctx, level); err != nil {
+		return err
+	}
+	fmt.Printf("Log level set to %s\n", level)
+	return nil
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+	"github.com/jbovet/mcp-cli/pkg/output"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNotification(method string, params map[string]any) mcp.JSONRPCNotification {
+	return mcp.JSONRPCNotification{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		Notification: mcp.Notification{
+			Method: method,
+			Params: mcp.NotificationParams{Meta: map[string]any{}, AdditionalFields: params},
+		},
+	}
+}
+
+func TestFormatNotification(t *testing.T) {
+	tests := []struct {
+		name         string
+		notification mcp.JSONRPCNotification
+		want         string
+	}{
+		{
+			name:         "log message",
+			notification: testNotification("notifications/message", map[string]any{"level": "warning", "logger": "db", "data": "slow query"}),
+			want:         "[warning] db: slow query",
+		},
+		{
+			name:         "structured log message",
+			notification: testNotification("notifications/message", map[string]any{"level": "info", "data": map[string]any{"disk": float64(91)}}),
+			want:         `[info] {"disk":91}`,
+		},
+		{
+			name:         "tool list changed",
+			notification: testNotification(mcp.MethodNotificationToolsListChanged, nil),
+			want:         "Tool list changed",
+		},
+		{
+			name:         "resource updated",
+			notification: testNotification(mcp.MethodNotificationResourceUpdated, map[string]any{"uri": "file:///log.txt"}),
+			want:         "Resource updated: file:///log.txt",
+		},
+		{
+			name:         "progress",
+			notification: testNotification("notifications/progress", map[string]any{"progressToken": "t", "progress": float64(3), "total": float64(10), "message": "copying"}),
+			want:         "Progress 3/10: copying",
+		},
+		{
+			name:         "cancelled",
+			notification: testNotification("notifications/cancelled", map[string]any{"requestId": float64(4), "reason": "timeout"}),
+			want:         "Request 4 cancelled: timeout",
+		},
+		{
+			name:         "other",
+			notification: testNotification("notifications/custom", map[string]any{"b": true, "a": "x"}),
+			want:         "notifications/custom a=x b=true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatNotification(tt.notification))
+		})
+	}
+}
+
+func TestNotificationPrinter(t *testing.T) {
+	notification := testNotification("notifications/message", map[string]any{"level": "info", "data": "ready"})
+
+	t.Run("text", func(t *testing.T) {
+		var out bytes.Buffer
+		printer := newNotificationPrinter(&out, output.FormatTable)
+		printer.now = func() time.Time { return time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC) }
+		printer.handle(notification)
+		assert.Equal(t, "15:04:05 [info] ready\n", out.String())
+	})
+
+	t.Run("json lines", func(t *testing.T) {
+		var out bytes.Buffer
+		printer := newNotificationPrinter(&out, output.FormatJSON)
+		printer.handle(notification)
+		printer.handle(testNotification(mcp.MethodNotificationPromptsListChanged, nil))
+		assert.Equal(t, `{"method":"notifications/message","params":{"data":"ready","level":"info"}}
+{"method":"notifications/prompts/list_changed","params":{}}
+`, out.String())
+	})
+}
+
+// lostAdapter is a server adapter whose connection is lost
+type lostAdapter struct {
+	adapter.ServerAdapter
+}
+
+func (lostAdapter) Done() <-chan struct{} {
+	done := make(chan struct{})
+	close(done)
+	return done
+}
+
+func (lostAdapter) Err() error {
+	return errors.New("server process exited")
+}
+
+func TestFollowNotificationsConnectionLost(t *testing.T) {
+	assert.EqualError(t, followNotifications(lostAdapter{}), "connection to the server lost: server process exited")
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/jbovet/mcp-cli/pkg/oauth"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// OAuth flags shared by the commands connecting to a server
+	oauthClientID     string
+	oauthClientSecret string
+	oauthScopes       []string
+	noBrowser         bool
+)
+
+// addOAuthFlags registers the flags configuring the OAuth authorization flow
+func addOAuthFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&oauthClientID, "oauth-client-id", "", "OAuth client ID (registered dynamically when not set)")
+	cmd.Flags().StringVar(&oauthClientSecret, "oauth-client-secret", "", "OAuth client secret for confidential clients")
+	cmd.Flags().StringArrayVar(&oauthScopes, "oauth-scope", nil, "OAuth scope to request (can be repeated)")
+	cmd.Flags().BoolVar(&noBrowser, "no-browser", false, "Print the OAuth authorization URL instead of opening a browser")
+}
+
+// oauthOptions returns the OAuth configuration used when an HTTP server
+// requires authorization
+func oauthOptions() *oauth.Options {
+	return &oauth.Options{
+		ClientID:     oauthClientID,
+		ClientSecret: oauthClientSecret,
+		Scopes:       oauthScopes,
+		OpenURL:      openAuthorizationURL,
+	}
+}
+
+// openAuthorizationURL asks the user to authorize mcp-cli. The URL is always
+// printed so the flow can be completed when no browser can be launched.
+func openAuthorizationURL(authURL string) error {
+	fmt.Fprintf(os.Stderr, "The server requires authorization. Open the following URL to continue:\n\n  %s\n\n", authURL)
+	if noBrowser {
+		return nil
+	}
+
+	if err := openBrowser(authURL); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to open browser: %v\n", err)
+	}
+	return nil
+}
+
+// openBrowser launches the default browser of the platform
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// Reap the launcher in the background
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
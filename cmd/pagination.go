@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Pagination flags shared by the commands connecting to a server
+	maxPages int
+	maxItems int
+)
+
+// addPaginationFlags registers the flags bounding the listings of tools,
+// resources and prompts
+func addPaginationFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&maxPages, "max-pages", adapter.DefaultMaxPages, "Maximum number of pages requested by a listing")
+	cmd.Flags().IntVar(&maxItems, "max-items", adapter.DefaultMaxItems, "Maximum number of items returned by a listing")
+}
+
+// validatePagination checks the pagination flags
+func validatePagination() error {
+	if maxPages < 1 {
+		return fmt.Errorf("invalid --max-pages %d: must be at least 1", maxPages)
+	}
+	if maxItems < 1 {
+		return fmt.Errorf("invalid --max-items %d: must be at least 1", maxItems)
+	}
+	return nil
+}
+
+// paginationOptions returns the limits of the listings of the server
+func paginationOptions() adapter.Pagination {
+	return adapter.Pagination{
+		MaxPages:  maxPages,
+		MaxItems:  maxItems,
+		Truncated: warnTruncated,
+	}
+}
+
+// warnTruncated tells the user a listing is incomplete. In interactive mode,
+// the listings completing a line are shown above it.
+func warnTruncated(warning string) {
+	replOutput.warn(warning)
+}
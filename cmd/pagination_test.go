@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginationFlags(t *testing.T) {
+	defer func(pages, items int) { maxPages, maxItems = pages, items }(maxPages, maxItems)
+
+	maxPages, maxItems = adapter.DefaultMaxPages, adapter.DefaultMaxItems
+	assert.NoError(t, validatePagination())
+
+	maxPages, maxItems = 5, 50
+	pagination := paginationOptions()
+	assert.Equal(t, 5, pagination.MaxPages)
+	assert.Equal(t, 50, pagination.MaxItems)
+	assert.NotNil(t, pagination.Truncated)
+
+	maxPages = 0
+	assert.ErrorContains(t, validatePagination(), "invalid --max-pages 0")
+
+	maxPages, maxItems = 5, -1
+	assert.ErrorContains(t, validatePagination(), "invalid --max-items -1")
+}
@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/jbovet/mcp-cli/pkg/client"
+	"github.com/jbovet/mcp-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
@@ -31,7 +33,11 @@ func runPingCommand(cmd *cobra.Command, args []string) error {
 	}
 
 	if verbose {
-		fmt.Printf("Ping completed successfully\n")
+		fmt.Fprintf(os.Stderr, "Ping completed successfully\n")
+	}
+
+	if format := selectedOutputFormat(); format.IsStructured() {
+		return output.Write(os.Stdout, format, ping)
 	}
 
 	// Display ping response
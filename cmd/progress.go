@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+	"github.com/mark3labs/mcp-go/mcp"
+	"golang.org/x/term"
+)
+
+// progressBarWidth is the number of cells of a progress bar
+const progressBarWidth = 30
+
+// errInterrupted is the cause of a request cancelled with Ctrl+C, which is
+// sent to the server as the reason of the cancellation
+var errInterrupted = errors.New("interrupted by user")
+
+// toolProgress renders the progress of the tool called in interactive mode
+var toolProgress = newProgressBar(os.Stdout)
+
+// progressBar renders notifications/progress as a bar redrawn in place on a
+// terminal, or as one line per update otherwise. Only the updates of the
+// request started with start are shown, until done, as a server may still
+// report the progress of a cancelled request or of requests of its own.
+type progressBar struct {
+	mu       sync.Mutex
+	w        io.Writer
+	terminal bool
+	active   bool
+	drawn    bool
+	// token is the progress token of the current request, and requests the
+	// number of requests started
+	token    string
+	requests int
+}
+
+func newProgressBar(f *os.File) *progressBar {
+	return &progressBar{w: f, terminal: term.IsTerminal(int(f.Fd()))}
+}
+
+// handle renders a progress notification and reports whether notification
+// was one
+func (p *progressBar) handle(notification mcp.JSONRPCNotification) bool {
+	if notification.Method != "notifications/progress" {
+		return false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.active || notification.Params.AdditionalFields["progressToken"] != p.token {
+		return true
+	}
+	line := formatProgress(notification.Params.AdditionalFields)
+	if p.terminal {
+		fmt.Fprintf(p.w, "\r\033[K%s", line)
+		p.drawn = true
+	} else {
+		fmt.Fprintln(p.w, line)
+	}
+	return true
+}
+
+// start shows the progress updates of the request made with the returned
+// context until done is called
+func (p *progressBar) start(ctx context.Context) context.Context {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.active = true
+	p.requests++
+	p.token = fmt.Sprintf("mcp-cli-%d", p.requests)
+	return adapter.WithProgressToken(ctx, p.token)
+}
+
+// done stops showing progress updates and ends the line of a bar drawn in
+// place, so that the next output starts on its own line
+func (p *progressBar) done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.active = false
+	if p.drawn {
+		fmt.Fprintln(p.w)
+		p.drawn = false
+	}
+}
+
+// formatProgress describes the progress of a request as a bar with its
+// percentage when the total is known, followed by the progress message
+func formatProgress(params map[string]any) string {
+	var line string
+	progress, _ := params["progress"].(float64)
+	if total, ok := params["total"].(float64); ok && total > 0 {
+		ratio := min(max(progress/total, 0), 1)
+		filled := int(ratio * progressBarWidth)
+		line = fmt.Sprintf("[%s%s] %3.0f%%", strings.Repeat("#", filled), strings.Repeat("-", progressBarWidth-filled), ratio*100)
+	} else {
+		line = "Progress " + formatValue(params["progress"])
+	}
+	if message := stringParam(params, "message"); message != "" {
+		line += " " + message
+	}
+	return line
+}
+
+// interruptible returns a context cancelled with errInterrupted when the
+// user presses Ctrl+C, instead of the CLI exiting, until stop is called
+func interruptible(ctx context.Context) (_ context.Context, stop func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-signals:
+			cancel(errInterrupted)
+		case <-done:
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(signals)
+		close(done)
+		cancel(nil)
+	}
+}
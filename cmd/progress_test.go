@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatProgress(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]any
+		want   string
+	}{
+		{
+			name:   "with total",
+			params: map[string]any{"progress": float64(3), "total": float64(10), "message": "copying"},
+			want:   "[#########---------------------]  30% copying",
+		},
+		{
+			name:   "complete",
+			params: map[string]any{"progress": float64(10), "total": float64(10)},
+			want:   "[##############################] 100%",
+		},
+		{
+			name:   "beyond total",
+			params: map[string]any{"progress": float64(12), "total": float64(10)},
+			want:   "[##############################] 100%",
+		},
+		{
+			name:   "without total",
+			params: map[string]any{"progress": float64(42), "message": "rows indexed"},
+			want:   "Progress 42 rows indexed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatProgress(tt.params))
+		})
+	}
+}
+
+func TestProgressBar(t *testing.T) {
+	update := func(progress float64) mcp.JSONRPCNotification {
+		return testNotification("notifications/progress", map[string]any{"progressToken": "mcp-cli-1", "progress": progress, "total": float64(2)})
+	}
+
+	t.Run("terminal", func(t *testing.T) {
+		var out bytes.Buffer
+		bar := &progressBar{w: &out, terminal: true}
+		bar.start(context.Background())
+		assert.False(t, bar.handle(testNotification(mcp.MethodNotificationToolsListChanged, nil)))
+		assert.True(t, bar.handle(update(1)))
+		assert.True(t, bar.handle(update(2)))
+		bar.done()
+		bar.done()
+		// Updates arriving after the call are dropped
+		assert.True(t, bar.handle(update(2)))
+		assert.Equal(t, "\r\033[K[###############---------------]  50%\r\033[K[##############################] 100%\n", out.String())
+	})
+
+	t.Run("not a terminal", func(t *testing.T) {
+		var out bytes.Buffer
+		bar := &progressBar{w: &out}
+		bar.start(context.Background())
+		bar.handle(update(1))
+		bar.done()
+		assert.Equal(t, "[###############---------------]  50%\n", out.String())
+	})
+
+	t.Run("other requests", func(t *testing.T) {
+		var out bytes.Buffer
+		bar := &progressBar{w: &out}
+		bar.start(context.Background())
+		bar.done()
+		// The second request has its own token
+		bar.start(context.Background())
+		assert.True(t, bar.handle(update(1)))
+		assert.True(t, bar.handle(testNotification("notifications/progress", map[string]any{"progressToken": float64(7), "progress": float64(1)})))
+		bar.handle(testNotification("notifications/progress", map[string]any{"progressToken": "mcp-cli-2", "progress": float64(3)}))
+		bar.done()
+		assert.Equal(t, "Progress 3\n", out.String())
+	})
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jbovet/mcp-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// promptCmd represents the prompt command
+var promptCmd = &cobra.Command{
+	Use:   "prompt <prompt-name>",
+	Short: "Get a prompt from an MCP server and print its messages",
+	Long: `Connect to an MCP server, get a single prompt and print its messages.
+
+Arguments can be passed as key=value pairs with --arg, as a JSON object with
+--json, or read from a file (or stdin with "-") with --json-file. The command
+exits with a non-zero status when the transport fails or the prompt cannot be
+retrieved.`,
+	Example: `  # Get a prompt from a stdio server
+  mcp-cli prompt summarize --command "python server.py" --arg topic=mcp
+
+  # Get a prompt from an HTTP server with JSON arguments
+  mcp-cli prompt review --type http --url "http://localhost:8080/mcp" --json '{"language": "go"}'`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPromptCommand,
+}
+
+func runPromptCommand(cmd *cobra.Command, args []string) error {
+	promptName := args[0]
+
+	baseArguments, err := readJSONArguments(oneShotJSON, oneShotJSONFile)
+	if err != nil {
+		return err
+	}
+
+	arguments, err := promptArguments(baseArguments, oneShotArgs)
+	if err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	connectCtx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+	defer cancel()
+
+	serverAdapter, err := connectToServer(connectCtx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := serverAdapter.Disconnect(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to disconnect: %v\n", err)
+		}
+	}()
+
+	// Requests are not bound to the connection timeout
+	ctx := context.Background()
+
+	result, err := serverAdapter.GetPrompt(ctx, promptName, arguments)
+	if err != nil {
+		return err
+	}
+
+	if format := selectedOutputFormat(); format.IsStructured() {
+		return output.Write(os.Stdout, format, result)
+	}
+
+	return writePromptResult(os.Stdout, result)
+}
+
+func init() {
+	rootCmd.AddCommand(promptCmd)
+
+	addTransportFlags(promptCmd)
+	addArgumentFlags(promptCmd)
+}
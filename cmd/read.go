@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jbovet/mcp-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// readCmd represents the read command
+var readCmd = &cobra.Command{
+	Use:   "read <resource-uri | template-name> [key=value...]",
+	Short: "Read a resource from an MCP server and print its content",
+	Long: `Connect to an MCP server, read a single resource and print its content.
+
+Text contents are written as-is and binary (blob) contents are decoded, so the
+output can be redirected to a file. The command exits with a non-zero status
+when the transport fails or the resource cannot be read.
+
+Instead of a URI, the name of a resource template can be given followed by
+key=value pairs filling its variables. The template is expanded following
+RFC 6570, and variables given several times become lists.`,
+	Example: `  # Read a resource from a stdio server
+  mcp-cli read file:///config.json --command "python server.py"
+
+  # Save a binary resource from an HTTP server
+  mcp-cli read images://logo --type http --url "http://localhost:8080/mcp" > logo.png
+
+  # Read the resource of a template
+  mcp-cli read user-profile id=42 --server my-server`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runReadCommand,
+}
+
+func runReadCommand(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	connectCtx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+	defer cancel()
+
+	serverAdapter, err := connectToServer(connectCtx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := serverAdapter.Disconnect(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to disconnect: %v\n", err)
+		}
+	}()
+
+	// Requests are not bound to the connection timeout
+	ctx := context.Background()
+
+	uri, err := resolveResourceURI(ctx, serverAdapter, args[0], args[1:])
+	if err != nil {
+		return err
+	}
+
+	result, err := serverAdapter.ReadResource(ctx, uri)
+	if err != nil {
+		return err
+	}
+
+	if format := selectedOutputFormat(); format.IsStructured() {
+		return output.Write(os.Stdout, format, result)
+	}
+
+	return writeResourceContents(os.Stdout, result.Contents)
+}
+
+func init() {
+	rootCmd.AddCommand(readCmd)
+
+	addTransportFlags(readCmd)
+}
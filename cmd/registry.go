@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+	"github.com/jbovet/mcp-cli/pkg/client"
+	"github.com/jbovet/mcp-cli/pkg/inputs"
+	"github.com/jbovet/mcp-cli/pkg/models"
+)
+
+// createRegistryAdapter creates the adapter for the registry server given
+// with --registry, using the remote or package selected with --remote or
+// --package. Without either, the first supported remote is preferred over
+// running a package.
+func createRegistryAdapter() (adapter.ServerAdapter, adapter.AdapterType, error) {
+	if registryRemote != 0 && registryPackage != 0 {
+		return nil, "", fmt.Errorf("--remote and --package cannot be used together")
+	}
+
+	url := registryURL
+	if url == "" {
+		url = baseURL
+	}
+	if verbose {
+		fmt.Fprintf(os.Stderr, "Looking up server by name: %s\n", registryServer)
+	}
+	server, err := client.NewClient(url).GetServerByName(registryServer)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch server: %w", err)
+	}
+
+	resolver, err := newInputResolver()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if registryPackage == 0 {
+		remote, index, err := selectRemote(server, registryRemote)
+		if err == nil {
+			fmt.Fprintf(os.Stderr, "Using remote %d of %s: %s (%s)\n", index, server.Name, remote.URL, remote.TransportType)
+			return createRemoteAdapter(remote, resolver)
+		}
+		if registryRemote != 0 || len(server.Packages) == 0 {
+			return nil, "", err
+		}
+	}
+
+	pkg, err := selectPackage(server, registryPackage)
+	if err != nil {
+		return nil, "", err
+	}
+	fmt.Fprintf(os.Stderr, "Running %s package %s of %s\n", pkg.RegistryName, pkg.Name, server.Name)
+
+	entry, err := packageServer(pkg, resolver)
+	if err != nil {
+		return nil, "", err
+	}
+	return createServerAdapter(entry, resolver.Secrets())
+}
+
+// createRemoteAdapter creates the adapter of a registry remote, asking for
+// the headers it declares
+func createRemoteAdapter(remote models.Remote, resolver *inputs.Resolver) (adapter.ServerAdapter, adapter.AdapterType, error) {
+	adapterType, err := remoteAdapterType(remote.TransportType)
+	if err != nil {
+		return nil, "", err
+	}
+
+	headers, err := parseHeaders(connectHeaders, bearerToken)
+	if err != nil {
+		return nil, "", err
+	}
+	if headers, err = promptRemoteHeaders(remote, headers, resolver); err != nil {
+		return nil, "", err
+	}
+
+	serverAdapter, err := adapter.NewAdapter(adapterType, adapter.Config{
+		ServerURL: remote.URL,
+		Headers:   headers,
+		OAuth:     oauthOptions(),
+		Timeout:   connectTimeout,
+		Verbose:   verbose,
+		Secrets:   resolver.Secrets(),
+
+		Pagination: paginationOptions(),
+	})
+	return serverAdapter, adapterType, err
+}
+
+// selectRemote returns the remote with the given 1-based index, or the first
+// remote with a supported transport when index is 0, along with its index
+func selectRemote(server *client.ServerDetail, index int) (models.Remote, int, error) {
+	if len(server.Remotes) == 0 {
+		return models.Remote{}, 0, fmt.Errorf("server %s has no remotes", server.Name)
+	}
+
+	if index != 0 {
+		if index < 1 || index > len(server.Remotes) {
+			return models.Remote{}, 0, fmt.Errorf("invalid remote %d: server %s has %d remote(s)", index, server.Name, len(server.Remotes))
+		}
+		return server.Remotes[index-1], index, nil
+	}
+
+	for i, remote := range server.Remotes {
+		if _, err := remoteAdapterType(remote.TransportType); err == nil {
+			return remote, i + 1, nil
+		}
+	}
+	return models.Remote{}, 0, fmt.Errorf("server %s has no remote with a supported transport (streamable, sse)", server.Name)
+}
+
+// remoteAdapterType maps the transport type of a registry remote to an
+// adapter type
+func remoteAdapterType(transportType string) (adapter.AdapterType, error) {
+	switch strings.ToLower(transportType) {
+	case "streamable", "streamable-http":
+		return adapter.AdapterTypeStreamable, nil
+	case "sse":
+		return adapter.AdapterTypeSSE, nil
+	case "http":
+		return adapter.AdapterTypeAuto, nil
+	default:
+		return "", fmt.Errorf("unsupported remote transport type %q", transportType)
+	}
+}
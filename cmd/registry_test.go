@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+	"github.com/jbovet/mcp-cli/pkg/client"
+	"github.com/jbovet/mcp-cli/pkg/models"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteAdapterType(t *testing.T) {
+	tests := map[string]adapter.AdapterType{
+		"streamable":      adapter.AdapterTypeStreamable,
+		"streamable-http": adapter.AdapterTypeStreamable,
+		"sse":             adapter.AdapterTypeSSE,
+		"http":            adapter.AdapterTypeAuto,
+	}
+	for transportType, expected := range tests {
+		adapterType, err := remoteAdapterType(transportType)
+		require.NoError(t, err)
+		assert.Equal(t, expected, adapterType)
+	}
+
+	_, err := remoteAdapterType("websocket")
+	assert.ErrorContains(t, err, "unsupported remote transport type")
+}
+
+func TestSelectRemote(t *testing.T) {
+	detail := &client.ServerDetail{
+		Server: models.Server{Name: "io.github.owner/server"},
+		Remotes: []models.Remote{
+			{TransportType: "websocket", URL: "wss://example.com"},
+			{TransportType: "sse", URL: "https://example.com/sse"},
+		},
+	}
+
+	remote, index, err := selectRemote(detail, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, index)
+	assert.Equal(t, "https://example.com/sse", remote.URL)
+
+	_, _, err = selectRemote(detail, 3)
+	assert.ErrorContains(t, err, "has 2 remote(s)")
+
+	_, _, err = selectRemote(&client.ServerDetail{Server: models.Server{Name: "local"}}, 0)
+	assert.ErrorContains(t, err, "has no remotes")
+}
+
+func TestCreateRegistryAdapter(t *testing.T) {
+	mcpServer := server.NewTestStreamableHTTPServer(server.NewMCPServer("registry-test", "1.0.0"))
+	t.Cleanup(mcpServer.Close)
+
+	detail := models.ServerDetail{
+		Server: models.Server{
+			ID:            "123e4567-e89b-12d3-a456-426614174000",
+			Name:          "io.github.owner/server",
+			VersionDetail: models.VersionDetail{Version: "1.0.0", IsLatest: true},
+		},
+		Remotes:  []models.Remote{{TransportType: "streamable", URL: mcpServer.URL + "/mcp"}},
+		Packages: []models.Package{{RegistryName: "npm", Name: "@owner/server"}},
+	}
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v0/servers" {
+			_ = json.NewEncoder(w).Encode(client.ServersResponse{Servers: []models.Server{detail.Server}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(detail)
+	}))
+	t.Cleanup(registry.Close)
+
+	registryServer, registryURL = detail.Name, registry.URL
+	t.Cleanup(func() { registryServer, registryURL, registryRemote, registryPackage = "", "", 0, 0 })
+
+	t.Run("connects to the remote", func(t *testing.T) {
+		serverAdapter, adapterType, err := createAdapter()
+		require.NoError(t, err)
+		assert.Equal(t, adapter.AdapterTypeStreamable, adapterType)
+
+		require.NoError(t, serverAdapter.Connect(context.Background()))
+		defer func() { _ = serverAdapter.Disconnect() }()
+		info, err := serverAdapter.GetServerInfo()
+		require.NoError(t, err)
+		assert.Equal(t, "registry-test", info.Name)
+	})
+
+	t.Run("runs the package", func(t *testing.T) {
+		registryPackage = 1
+		defer func() { registryPackage = 0 }()
+
+		serverAdapter, adapterType, err := createAdapter()
+		require.NoError(t, err)
+		assert.Equal(t, adapter.AdapterTypeStdio, adapterType)
+		assert.NotNil(t, serverAdapter)
+	})
+
+	t.Run("remote and package are exclusive", func(t *testing.T) {
+		registryRemote, registryPackage = 1, 1
+		defer func() { registryRemote, registryPackage = 0, 0 }()
+
+		_, _, err := createAdapter()
+		assert.ErrorContains(t, err, "--remote and --package cannot be used together")
+	})
+}
@@ -1,13 +1,16 @@
 package cmd
 
 import (
+	"github.com/jbovet/mcp-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
 var (
 	// Global flags
-	baseURL string
-	verbose bool
+	baseURL      string
+	verbose      bool
+	outputFormat string
+	configPath   string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -28,7 +31,14 @@ This CLI allows you to:
   mcp-cli get server 123e4567-e89b-12d3-a456-426614174000
 
   # Check service health
-  mcp-cli health`,
+  mcp-cli health
+
+  # Print the server list as JSON
+  mcp-cli get servers --output json`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		_, err := output.ParseFormat(outputFormat)
+		return err
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -39,4 +49,24 @@ func Execute() error {
 func init() {
 	rootCmd.PersistentFlags().StringVar(&baseURL, "url", "http://localhost:8080", "Base URL of the MCP Registry Service")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", string(output.FormatTable), "Output format (table, wide, json, yaml)")
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "Server configuration file (default ~/.config/mcp-cli/servers.yaml)")
+}
+
+// selectedOutputFormat returns the output format chosen with --output
+func selectedOutputFormat() output.Format {
+	format, err := output.ParseFormat(outputFormat)
+	if err != nil {
+		return output.FormatTable
+	}
+	return format
+}
+
+// truncateText shortens text to width characters for table output. Text is
+// never truncated with the wide output format.
+func truncateText(text string, width int) string {
+	if selectedOutputFormat() == output.FormatWide || len(text) <= width {
+		return text
+	}
+	return text[:width-3] + "..."
 }
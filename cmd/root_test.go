@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/jbovet/mcp-cli/pkg/output"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -32,6 +33,11 @@ func TestRootCommand(t *testing.T) {
 		assert.NotNil(t, verboseFlag)
 		assert.Equal(t, "false", verboseFlag.DefValue)
 		assert.Equal(t, "v", verboseFlag.Shorthand)
+
+		outputFlag := rootCmd.PersistentFlags().Lookup("output")
+		assert.NotNil(t, outputFlag)
+		assert.Equal(t, "table", outputFlag.DefValue)
+		assert.Equal(t, "o", outputFlag.Shorthand)
 	})
 
 	t.Run("execute", func(t *testing.T) {
@@ -56,4 +62,26 @@ func TestFlagValues(t *testing.T) {
 		assert.NoError(t, err)
 		assert.True(t, verbose)
 	})
+
+	t.Run("output flag", func(t *testing.T) {
+		defer func() { outputFormat = string(output.FormatTable) }()
+
+		err := rootCmd.PersistentFlags().Set("output", "json")
+		assert.NoError(t, err)
+		assert.Equal(t, output.FormatJSON, selectedOutputFormat())
+
+		outputFormat = "xml"
+		assert.Error(t, rootCmd.PersistentPreRunE(rootCmd, nil))
+	})
+}
+
+func TestTruncateText(t *testing.T) {
+	defer func() { outputFormat = string(output.FormatTable) }()
+
+	text := "a description longer than ten characters"
+	assert.Equal(t, "a descr...", truncateText(text, 10))
+	assert.Equal(t, "short", truncateText("short", 10))
+
+	outputFormat = string(output.FormatWide)
+	assert.Equal(t, text, truncateText(text, 10))
 }
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+)
+
+var (
+	// connectRoots is the --root flag
+	connectRoots []string
+
+	// serverRoots are the roots offered by connectToServer
+	serverRoots *adapter.Roots
+)
+
+// newRoots creates the roots given with --root, or the working directory
+// when none is given
+func newRoots(pathsOrURIs []string) (*adapter.Roots, error) {
+	if len(pathsOrURIs) == 0 {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get working directory: %w", err)
+		}
+		pathsOrURIs = []string{wd}
+	}
+
+	roots := adapter.NewRoots()
+	for _, pathOrURI := range pathsOrURIs {
+		root, err := adapter.ParseRoot(pathOrURI)
+		if err != nil {
+			return nil, err
+		}
+		roots.Add(root)
+	}
+	return roots, nil
+}
+
+// rootsInteractive lists the roots, or adds or removes one and tells the
+// server that they changed
+func rootsInteractive(ctx context.Context, serverAdapter adapter.ServerAdapter, roots *adapter.Roots, args []string) error {
+	if len(args) == 0 {
+		return writeRoots(os.Stdout, roots)
+	}
+	usage := fmt.Errorf("invalid roots command (usage: roots | roots add <path-or-uri> | roots remove <path-or-uri>)")
+	if len(args) != 2 {
+		return usage
+	}
+
+	switch args[0] {
+	case "add":
+		root, err := adapter.ParseRoot(args[1])
+		if err != nil {
+			return err
+		}
+		if !roots.Add(root) {
+			fmt.Printf("Root %s is already listed\n", root.URI)
+			return nil
+		}
+		fmt.Printf("Added root %s\n", root.URI)
+	case "remove":
+		uri, err := adapter.RootURI(args[1])
+		if err != nil {
+			return err
+		}
+		if !roots.Remove(uri) {
+			fmt.Printf("Root %s is not listed\n", uri)
+			return nil
+		}
+		fmt.Printf("Removed root %s\n", uri)
+	default:
+		return usage
+	}
+
+	return serverAdapter.NotifyRootsChanged(ctx)
+}
+
+// writeRoots lists the roots like the other listings of interactive mode
+func writeRoots(w io.Writer, roots *adapter.Roots) error {
+	list := roots.List()
+	if len(list) == 0 {
+		_, err := fmt.Fprintln(w, "No roots offered")
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "Roots offered to the server (%d):\n", len(list)); err != nil {
+		return err
+	}
+	for i, root := range list {
+		if _, err := fmt.Fprintf(w, "%d. %s (%s)\n", i+1, root.URI, root.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRoots(t *testing.T) {
+	t.Run("working directory by default", func(t *testing.T) {
+		wd, err := os.Getwd()
+		require.NoError(t, err)
+		want, err := adapter.ParseRoot(wd)
+		require.NoError(t, err)
+
+		roots, err := newRoots(nil)
+		require.NoError(t, err)
+		assert.Equal(t, []mcp.Root{want}, roots.List())
+	})
+
+	t.Run("given roots", func(t *testing.T) {
+		roots, err := newRoots([]string{"file:///src/app", "file:///src/lib", "file:///src/app"})
+		require.NoError(t, err)
+		assert.Equal(t, []mcp.Root{
+			{URI: "file:///src/app", Name: "app"},
+			{URI: "file:///src/lib", Name: "lib"},
+		}, roots.List())
+	})
+
+	t.Run("invalid root", func(t *testing.T) {
+		_, err := newRoots([]string{"s3://bucket"})
+		assert.ErrorContains(t, err, "only file:// URIs are supported")
+	})
+}
+
+func TestWriteRoots(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeRoots(&out, adapter.NewRoots()))
+	assert.Equal(t, "No roots offered\n", out.String())
+
+	out.Reset()
+	require.NoError(t, writeRoots(&out, adapter.NewRoots(
+		mcp.Root{URI: "file:///src/app", Name: "app"},
+		mcp.Root{URI: "file:///src/lib", Name: "lib"},
+	)))
+	assert.Equal(t, `Roots offered to the server (2):
+1. file:///src/app (app)
+2. file:///src/lib (lib)
+`, out.String())
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+	"github.com/jbovet/mcp-cli/pkg/sampling"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// samplingInteractive is the --sampling value answering sampling requests
+// from the terminal, any other value is a file of scripted responses
+const samplingInteractive = "interactive"
+
+var (
+	// connectSampling is the --sampling flag
+	connectSampling string
+
+	// samplingHandler is set by connectToServer before connecting
+	samplingHandler adapter.SamplingHandler
+)
+
+// errSamplingDeclined rejects a sampling request the user did not answer
+var errSamplingDeclined = errors.New("sampling request declined by the user")
+
+// newSamplingHandler creates the handler selected with --sampling. Requests
+// are only answered interactively when terminal is set.
+func newSamplingHandler(mode string, terminal bool) (adapter.SamplingHandler, error) {
+	if mode == samplingInteractive {
+		if !terminal {
+			return nil, fmt.Errorf("--sampling %s needs a terminal and cannot be used with a script, use a file of scripted responses instead", samplingInteractive)
+		}
+		return &samplingPrompter{prompter: newPrompter(os.Stdin, os.Stderr)}, nil
+	}
+	return sampling.LoadScript(mode)
+}
+
+// samplingPrompter shows the sampling requests of the server and lets the
+// user type the response and approve sending it
+type samplingPrompter struct {
+	mu       sync.Mutex
+	prompter *prompter
+}
+
+// CreateMessage implements adapter.SamplingHandler. Requests are answered
+// one at a time, and in interactive mode only while a command runs.
+func (s *samplingPrompter) CreateMessage(ctx context.Context, request mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := replOutput.startPrompt(); err != nil {
+		return nil, fmt.Errorf("failed to answer sampling request: %w", err)
+	}
+	defer replOutput.endPrompt()
+
+	out := s.prompter.out
+	if err := writeSamplingRequest(out, request); err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintln(out, "Type the response, ending with an empty line (no response declines the request):"); err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for {
+		line, err := s.prompter.readLine(false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sampling response: %w", err)
+		}
+		if strings.TrimSpace(line) == "" {
+			break
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		fmt.Fprintln(out, "Sampling request declined")
+		return nil, errSamplingDeclined
+	}
+
+	fmt.Fprint(out, "Send this response? [Y/n]: ")
+	answer, err := s.prompter.readLine(false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sampling response: %w", err)
+	}
+	if answer = strings.ToLower(strings.TrimSpace(answer)); answer == "n" || answer == "no" {
+		fmt.Fprintln(out, "Sampling request declined")
+		return nil, errSamplingDeclined
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("sampling request expired before it was answered: %w", err)
+	}
+	return &mcp.CreateMessageResult{
+		SamplingMessage: mcp.SamplingMessage{
+			Role:    mcp.RoleAssistant,
+			Content: mcp.NewTextContent(strings.Join(lines, "\n")),
+		},
+		Model:      "human",
+		StopReason: "endTurn",
+	}, nil
+}
+
+// writeSamplingRequest shows the messages of a sampling request with the
+// preferences of the server
+func writeSamplingRequest(w io.Writer, request mcp.CreateMessageRequest) error {
+	details := []string{fmt.Sprintf("max %d tokens", request.MaxTokens)}
+	if preferences := request.ModelPreferences; preferences != nil && len(preferences.Hints) > 0 {
+		names := make([]string, len(preferences.Hints))
+		for i, hint := range preferences.Hints {
+			names[i] = hint.Name
+		}
+		details = append(details, "models: "+strings.Join(names, ", "))
+	}
+	if request.Temperature != 0 {
+		details = append(details, fmt.Sprintf("temperature %g", request.Temperature))
+	}
+	if _, err := fmt.Fprintf(w, "\nSampling request from the server (%s)\n", strings.Join(details, ", ")); err != nil {
+		return err
+	}
+
+	if request.SystemPrompt != "" {
+		if _, err := fmt.Fprintln(w, "System:"); err != nil {
+			return err
+		}
+		if err := writeIndented(w, request.SystemPrompt); err != nil {
+			return err
+		}
+	}
+	for _, message := range request.Messages {
+		if _, err := fmt.Fprintf(w, "%s:\n", roleLabel(message.Role)); err != nil {
+			return err
+		}
+		content, ok := message.Content.(mcp.Content)
+		if !ok {
+			if _, err := fmt.Fprintf(w, "  %v\n", message.Content); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := renderContent(w, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
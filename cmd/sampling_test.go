@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/term"
+)
+
+func testSamplingRequest() mcp.CreateMessageRequest {
+	request := mcp.CreateMessageRequest{}
+	request.SystemPrompt = "You are terse."
+	request.Messages = []mcp.SamplingMessage{
+		{Role: mcp.RoleUser, Content: mcp.NewTextContent("What is MCP?")},
+	}
+	request.ModelPreferences = &mcp.ModelPreferences{Hints: []mcp.ModelHint{{Name: "claude"}, {Name: "gpt"}}}
+	request.MaxTokens = 50
+	return request
+}
+
+func TestWriteSamplingRequest(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeSamplingRequest(&out, testSamplingRequest()))
+	assert.Equal(t, `
+Sampling request from the server (max 50 tokens, models: claude, gpt)
+System:
+  You are terse.
+User:
+  What is MCP?
+`, out.String())
+}
+
+func TestSamplingPrompter(t *testing.T) {
+	answer := func(input string) (*mcp.CreateMessageResult, string, error) {
+		var out bytes.Buffer
+		s := &samplingPrompter{prompter: newPrompter(strings.NewReader(input), &out)}
+		result, err := s.CreateMessage(context.Background(), testSamplingRequest())
+		return result, out.String(), err
+	}
+
+	t.Run("approved", func(t *testing.T) {
+		result, out, err := answer("A protocol\nfor tools\n\n\n")
+		require.NoError(t, err)
+		assert.Equal(t, mcp.RoleAssistant, result.Role)
+		assert.Equal(t, mcp.NewTextContent("A protocol\nfor tools"), result.Content)
+		assert.Equal(t, "human", result.Model)
+		assert.Contains(t, out, "Send this response? [Y/n]: ")
+	})
+
+	t.Run("not sent", func(t *testing.T) {
+		_, _, err := answer("A protocol\n\nn\n")
+		assert.ErrorIs(t, err, errSamplingDeclined)
+	})
+
+	t.Run("no response", func(t *testing.T) {
+		_, out, err := answer("\n")
+		assert.ErrorIs(t, err, errSamplingDeclined)
+		assert.NotContains(t, out, "Send this response?")
+	})
+}
+
+func TestSamplingPrompterDuringCommandRead(t *testing.T) {
+	replOutput.setTerminal(term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{strings.NewReader(""), io.Discard}, replPrompt))
+	defer replOutput.setTerminal(nil)
+
+	var out bytes.Buffer
+	s := &samplingPrompter{prompter: newPrompter(strings.NewReader("A protocol\n\n\n"), &out)}
+	_, err := s.CreateMessage(context.Background(), testSamplingRequest())
+	assert.ErrorIs(t, err, errCommandPrompt)
+	assert.Empty(t, out.String())
+}
+
+func TestNewSamplingHandler(t *testing.T) {
+	handler, err := newSamplingHandler(samplingInteractive, true)
+	require.NoError(t, err)
+	assert.IsType(t, &samplingPrompter{}, handler)
+
+	_, err = newSamplingHandler(samplingInteractive, false)
+	assert.ErrorContains(t, err, "--sampling interactive needs a terminal")
+}
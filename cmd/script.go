@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+)
+
+var (
+	// connectScript is the --script flag
+	connectScript string
+
+	// continueOnError is the --continue-on-error flag
+	continueOnError bool
+)
+
+// scriptMode reports whether connect runs a script of interactive mode
+// commands: the file given with --script, or stdin in interactive mode when
+// it is not a terminal
+func scriptMode() bool {
+	return connectScript != "" || interactiveMode && !stdinIsTerminal()
+}
+
+// runScriptMode runs the script selected by the connect flags
+func runScriptMode(ctx context.Context, serverAdapter adapter.ServerAdapter) error {
+	if connectScript == "" || connectScript == "-" {
+		return runScript(ctx, serverAdapter, "stdin", os.Stdin, continueOnError)
+	}
+
+	file, err := os.Open(connectScript)
+	if err != nil {
+		return fmt.Errorf("failed to open script: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+	return runScript(ctx, serverAdapter, connectScript, file, continueOnError)
+}
+
+// runScript runs the commands of a script through the dispatcher of
+// interactive mode, echoing each command after the prompt. Blank lines
+// and lines starting with # are skipped. The script stops at the first failed
+// command, unless continueOnError is set, and fails when any command failed.
+func runScript(ctx context.Context, serverAdapter adapter.ServerAdapter, name string, r io.Reader, continueOnError bool) error {
+	scanner := bufio.NewScanner(r)
+	// Allow long lines of inline JSON
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	// start is the line of the script where the command being read starts
+	var lineNumber, start int
+	readLine := func(prompt string) (string, error) {
+		for scanner.Scan() {
+			lineNumber++
+			line := scanner.Text()
+			trimmed := strings.TrimSpace(line)
+			// Comments are only recognized at the start of a command
+			if prompt == replPrompt && (trimmed == "" || strings.HasPrefix(trimmed, "#")) {
+				continue
+			}
+			if prompt == replPrompt {
+				start = lineNumber
+			}
+			return line, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+
+	var failed, total int
+	for {
+		command, err := readCommand(readLine)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read script %s: %w", name, err)
+		}
+
+		total++
+		command = strings.TrimSpace(command)
+		fmt.Printf("%s%s\n", replPrompt, command)
+		quit, err := runScriptCommand(ctx, serverAdapter, command)
+		if err != nil {
+			err = fmt.Errorf("%s:%d: %s: %w", name, start, commandName(command), err)
+			if !continueOnError {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			failed++
+		}
+		if quit {
+			break
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d commands of %s failed", failed, total, name)
+	}
+	return nil
+}
+
+// runScriptCommand runs a command of a script with the dispatcher of
+// interactive mode, except for the commands only usable interactively
+func runScriptCommand(ctx context.Context, serverAdapter adapter.ServerAdapter, command string) (bool, error) {
+	if commandName(command) == "watch" {
+		return false, errScriptWatch
+	}
+	return runReplCommand(ctx, serverAdapter, command)
+}
+
+// errScriptWatch refuses the watch command in scripts, where nothing would
+// end it
+var errScriptWatch = errors.New("watch runs until interrupted and cannot be used in a script")
+
+// commandName returns the first word of a command line
+func commandName(command string) string {
+	name, _, _ := strings.Cut(command, " ")
+	return name
+}
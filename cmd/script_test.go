@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunScript(t *testing.T) {
+	var calls []string
+	mcpServer := server.NewMCPServer("script-test", "1.0.0")
+	mcpServer.AddTool(mcp.NewTool("echo", mcp.WithString("message", mcp.Required())), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		message := request.GetString("message", "")
+		calls = append(calls, message)
+		return mcp.NewToolResultText(message), nil
+	})
+	mcpServer.AddTool(mcp.NewTool("fail"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls = append(calls, "fail")
+		return mcp.NewToolResultError("failed"), nil
+	})
+	mcpServer.AddTool(mcp.NewTool("create", mcp.WithArray("tags")), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls = append(calls, fmt.Sprint(request.GetArguments()["tags"]))
+		return mcp.NewToolResultText("created"), nil
+	})
+	testServer := server.NewTestStreamableHTTPServer(mcpServer)
+	defer testServer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	serverAdapter, err := adapter.NewAdapter(adapter.AdapterTypeAuto, adapter.Config{ServerURL: testServer.URL + "/mcp", Timeout: 5 * time.Second})
+	require.NoError(t, err)
+	require.NoError(t, serverAdapter.Connect(ctx))
+	defer func() { _ = serverAdapter.Disconnect() }()
+
+	run := func(script string, continueOnError bool) error {
+		calls = nil
+		return runScript(ctx, serverAdapter, "smoke.mcp", strings.NewReader(script), continueOnError)
+	}
+
+	t.Run("commands", func(t *testing.T) {
+		script := "# Smoke test\n\ntools\ncall echo hello\ncall create {\n  \"tags\": [\"a\", \"b\"]\n}\n"
+		require.NoError(t, run(script, false))
+		assert.Equal(t, []string{"hello", "[a b]"}, calls)
+	})
+
+	t.Run("stops at first error", func(t *testing.T) {
+		err := run("call echo one\n\ncall unknown\ncall echo two\n", false)
+		assert.EqualError(t, err, "smoke.mcp:3: call: unknown tool: unknown (type 'tools' to list available tools)")
+		assert.Equal(t, []string{"one"}, calls)
+	})
+
+	t.Run("tool error", func(t *testing.T) {
+		err := run("call fail\ncall echo two\n", false)
+		assert.ErrorIs(t, err, errToolResult)
+		assert.Equal(t, []string{"fail"}, calls)
+	})
+
+	t.Run("continue on error", func(t *testing.T) {
+		err := run("call fail\nbogus\ncall echo two\n", true)
+		assert.EqualError(t, err, "2 of 3 commands of smoke.mcp failed")
+		assert.Equal(t, []string{"fail", "two"}, calls)
+	})
+
+	t.Run("watch", func(t *testing.T) {
+		err := run("watch file:///notes.txt\ncall echo two\n", false)
+		assert.ErrorIs(t, err, errScriptWatch)
+		assert.ErrorContains(t, err, "smoke.mcp:1: watch: ")
+		assert.Empty(t, calls)
+	})
+
+	t.Run("quit", func(t *testing.T) {
+		require.NoError(t, run("call echo one\nquit\ncall echo two\n", false))
+		assert.Equal(t, []string{"one"}, calls)
+	})
+
+	t.Run("unterminated command", func(t *testing.T) {
+		err := run("call create {\"tags\": [\n", false)
+		assert.EqualError(t, err, "failed to read script smoke.mcp: braces or brackets left open: unexpected EOF")
+	})
+}
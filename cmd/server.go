@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"strings"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/jbovet/mcp-cli/pkg/client"
 	"github.com/jbovet/mcp-cli/pkg/models"
+	"github.com/jbovet/mcp-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
@@ -100,6 +102,9 @@ func runServerCommand(cmd *cobra.Command, args []string) error {
 					}
 				} else {
 					// Multiple matches, show them
+					if format := selectedOutputFormat(); format.IsStructured() {
+						return output.Write(os.Stdout, format, matches)
+					}
 					fmt.Printf("Multiple servers found matching '%s':\n\n", identifier)
 					return displayServerList(matches, "Use exact name or ID to get details")
 				}
@@ -113,6 +118,10 @@ func runServerCommand(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(os.Stderr, "Successfully fetched server details\n")
 	}
 
+	if format := selectedOutputFormat(); format.IsStructured() {
+		return output.Write(os.Stdout, format, server)
+	}
+
 	// Display server details
 	if err := displayServerDetails(server); err != nil {
 		return fmt.Errorf("failed to display server details: %w", err)
@@ -138,6 +147,13 @@ func showServerMatches(apiClient *client.Client, pattern string) error {
 		return fmt.Errorf("failed to search servers: %w", err)
 	}
 
+	if format := selectedOutputFormat(); format.IsStructured() {
+		if matches == nil {
+			matches = []models.Server{}
+		}
+		return output.Write(os.Stdout, format, matches)
+	}
+
 	if len(matches) == 0 {
 		fmt.Printf("No servers found matching pattern '%s'\n", pattern)
 		return nil
@@ -154,30 +170,51 @@ func displayServerList(servers []models.Server, footer string) error {
 		_ = w.Flush()
 	}()
 
+	writeServerRows(w, servers)
+
+	if footer != "" {
+		_, _ = fmt.Fprintf(w, "\n%s\n", footer)
+	}
+
+	return nil
+}
+
+// writeServerRows writes a server table. The wide output format adds the
+// release and repository columns and shows full descriptions.
+func writeServerRows(w io.Writer, servers []models.Server) {
+	wide := selectedOutputFormat() == output.FormatWide
+
 	// Print header
-	_, _ = fmt.Fprintln(w, "ID\tNAME\tVERSION\tDESCRIPTION")
-	_, _ = fmt.Fprintln(w, "---\t----\t-------\t-----------")
+	if wide {
+		_, _ = fmt.Fprintln(w, "ID\tNAME\tVERSION\tRELEASE DATE\tLATEST\tREPOSITORY\tDESCRIPTION")
+		_, _ = fmt.Fprintln(w, "---\t----\t-------\t------------\t------\t----------\t-----------")
+	} else {
+		_, _ = fmt.Fprintln(w, "ID\tNAME\tVERSION\tDESCRIPTION")
+		_, _ = fmt.Fprintln(w, "---\t----\t-------\t-----------")
+	}
 
 	// Print each server
 	for _, server := range servers {
-		description := server.Description
-		if len(description) > 50 {
-			description = description[:47] + "..."
+		if wide {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\t%s\t%s\n",
+				server.ID,
+				server.Name,
+				server.VersionDetail.Version,
+				server.VersionDetail.ReleaseDate,
+				server.VersionDetail.IsLatest,
+				server.Repository.URL,
+				server.Description,
+			)
+			continue
 		}
 
 		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
 			server.ID,
 			server.Name,
 			server.VersionDetail.Version,
-			description,
+			truncateText(server.Description, 50),
 		)
 	}
-
-	if footer != "" {
-		_, _ = fmt.Fprintf(w, "\n%s\n", footer)
-	}
-
-	return nil
 }
 
 func displayServerDetails(server *client.ServerDetail) error {
@@ -277,7 +314,7 @@ func displayServerDetails(server *client.ServerDetail) error {
 			if len(remote.Headers) > 0 {
 				fmt.Printf("   Headers:\n")
 				for _, header := range remote.Headers {
-					fmt.Printf("     %s: %s\n", header.Value, header.Description)
+					fmt.Printf("     %s: %s\n", header.Name, header.Description)
 				}
 			}
 			if i < len(server.Remotes)-1 {
@@ -6,6 +6,7 @@ import (
 	"text/tabwriter"
 
 	"github.com/jbovet/mcp-cli/pkg/client"
+	"github.com/jbovet/mcp-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
@@ -30,7 +31,10 @@ showing their basic information including ID, name, description, and version.`,
   mcp-cli get servers --limit 10
 
   # Continue from a specific cursor
-  mcp-cli get servers --cursor 123e4567-e89b-12d3-a456-426614174000`,
+  mcp-cli get servers --cursor 123e4567-e89b-12d3-a456-426614174000
+
+  # Print the full response as JSON
+  mcp-cli get servers --output json`,
 	RunE: runServersCommand,
 }
 
@@ -48,6 +52,10 @@ func runServersCommand(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(os.Stderr, "Fetched %d servers\n", len(response.Servers))
 	}
 
+	if format := selectedOutputFormat(); format.IsStructured() {
+		return output.Write(os.Stdout, format, response)
+	}
+
 	// Display results in table format
 	if err := displayServersTable(response); err != nil {
 		return fmt.Errorf("failed to display servers: %w", err)
@@ -68,24 +76,7 @@ func displayServersTable(response *client.ServersResponse) error {
 		_ = w.Flush()
 	}()
 
-	// Print header
-	_, _ = fmt.Fprintln(w, "ID\tNAME\tVERSION\tDESCRIPTION")
-	_, _ = fmt.Fprintln(w, "---\t----\t-------\t-----------")
-
-	// Print each server
-	for _, server := range response.Servers {
-		description := server.Description
-		if len(description) > 50 {
-			description = description[:47] + "..."
-		}
-
-		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-			server.ID,
-			server.Name,
-			server.VersionDetail.Version,
-			description,
-		)
-	}
+	writeServerRows(w, response.Servers)
 
 	// Print pagination info if available
 	if response.Metadata.NextCursor != "" {
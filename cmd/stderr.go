@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+)
+
+// stderrLog is the --stderr-log flag
+var stderrLog string
+
+// stderrInteractive shows the last lines the server wrote to stderr, all the
+// kept ones or the number given
+func stderrInteractive(serverAdapter adapter.ServerAdapter, args []string) error {
+	provider, ok := serverAdapter.(adapter.StderrProvider)
+	if !ok {
+		return fmt.Errorf("stderr is only captured for servers run with the stdio transport")
+	}
+
+	lines := provider.Stderr()
+	switch len(args) {
+	case 0:
+	case 1:
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid number of lines: %s (usage: stderr [lines])", args[0])
+		}
+		lines = lines[max(len(lines)-n, 0):]
+	default:
+		return fmt.Errorf("too many arguments (usage: stderr [lines])")
+	}
+	return writeStderr(os.Stdout, lines)
+}
+
+// writeStderr writes lines of the stderr of the server
+func writeStderr(w io.Writer, lines []string) error {
+	if len(lines) == 0 {
+		_, err := fmt.Fprintln(w, "The server has not written to stderr")
+		return err
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
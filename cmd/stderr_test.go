@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stderrAdapter is a server adapter keeping stderr lines
+type stderrAdapter struct {
+	adapter.ServerAdapter
+	lines []string
+}
+
+func (a stderrAdapter) Stderr() []string {
+	return a.lines
+}
+
+func TestStderrInteractive(t *testing.T) {
+	serverAdapter := stderrAdapter{lines: []string{"one", "two", "three"}}
+	assert.NoError(t, stderrInteractive(serverAdapter, []string{"2"}))
+	assert.EqualError(t, stderrInteractive(serverAdapter, []string{"0"}), "invalid number of lines: 0 (usage: stderr [lines])")
+	assert.EqualError(t, stderrInteractive(serverAdapter, []string{"1", "2"}), "too many arguments (usage: stderr [lines])")
+
+	httpAdapter, err := adapter.NewHTTPAdapter(adapter.Config{ServerURL: "http://localhost/mcp"})
+	require.NoError(t, err)
+	assert.ErrorContains(t, stderrInteractive(httpAdapter, nil), "only captured for servers run with the stdio transport")
+}
+
+func TestWriteStderr(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeStderr(&out, []string{"starting", "ready"}))
+	assert.Equal(t, "starting\nready\n", out.String())
+
+	out.Reset()
+	require.NoError(t, writeStderr(&out, nil))
+	assert.Equal(t, "The server has not written to stderr\n", out.String())
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/yosida95/uritemplate/v3"
+)
+
+// resolveResourceURI returns the URI to read for the arguments of read: a
+// resource URI, or the name (or URI template) of a resource template followed
+// by key=value pairs filling its variables. Templates are only looked up when
+// the argument does not look like a plain URI or pairs are given.
+func resolveResourceURI(ctx context.Context, serverAdapter adapter.ServerAdapter, arg string, pairs []string) (string, error) {
+	if len(pairs) == 0 && strings.Contains(arg, ":") && !strings.Contains(arg, "{") {
+		return arg, nil
+	}
+
+	templates, err := serverAdapter.ListResourceTemplates(ctx)
+	if err != nil {
+		if len(pairs) == 0 {
+			return arg, nil
+		}
+		return "", err
+	}
+
+	template, ok := findResourceTemplate(templates, arg)
+	if !ok {
+		if len(pairs) == 0 {
+			return arg, nil
+		}
+		return "", fmt.Errorf("unknown resource template: %s", arg)
+	}
+	return expandResourceTemplate(template, pairs)
+}
+
+// findResourceTemplate looks up a resource template by name or URI template
+func findResourceTemplate(templates []mcp.ResourceTemplate, name string) (mcp.ResourceTemplate, bool) {
+	for _, template := range templates {
+		if template.Name == name || templateURI(template) == name {
+			return template, true
+		}
+	}
+	return mcp.ResourceTemplate{}, false
+}
+
+// expandResourceTemplate expands a resource template following RFC 6570.
+// Each pair sets a variable and variables given several times become lists.
+// Variables left out are undefined and expand to nothing.
+func expandResourceTemplate(template mcp.ResourceTemplate, pairs []string) (string, error) {
+	if template.URITemplate == nil || template.URITemplate.Template == nil {
+		return "", fmt.Errorf("resource template %s has no URI template", template.Name)
+	}
+
+	variables := template.URITemplate.Varnames()
+	lists := make(map[string][]string, len(pairs))
+	var names []string
+	for _, pair := range pairs {
+		name, value, found := strings.Cut(pair, "=")
+		if !found || name == "" {
+			return "", fmt.Errorf("invalid variable %q, expected key=value", pair)
+		}
+		if !slices.Contains(variables, name) {
+			return "", fmt.Errorf("unknown variable %s for resource template %s (variables: %s)", name, template.Name, strings.Join(variables, ", "))
+		}
+		if _, seen := lists[name]; !seen {
+			names = append(names, name)
+		}
+		lists[name] = append(lists[name], value)
+	}
+
+	values := uritemplate.Values{}
+	for _, name := range names {
+		if list := lists[name]; len(list) == 1 {
+			values.Set(name, uritemplate.String(list[0]))
+		} else {
+			values.Set(name, uritemplate.List(list...))
+		}
+	}
+
+	uri, err := template.URITemplate.Expand(values)
+	if err != nil {
+		return "", fmt.Errorf("failed to expand resource template %s: %w", template.Name, err)
+	}
+	return uri, nil
+}
+
+// templateURI returns the URI template of a resource template as a string
+func templateURI(template mcp.ResourceTemplate) string {
+	if template.URITemplate == nil || template.URITemplate.Template == nil {
+		return ""
+	}
+	return template.URITemplate.Raw()
+}
+
+// templateUsage describes how to read a resource template in interactive mode
+func templateUsage(template mcp.ResourceTemplate) string {
+	usage := "read " + template.Name
+	if template.URITemplate != nil && template.URITemplate.Template != nil {
+		for _, name := range template.URITemplate.Varnames() {
+			usage += fmt.Sprintf(" %s=<%s>", name, name)
+		}
+	}
+	return usage
+}
+
+func listResourceTemplatesInteractive(ctx context.Context, serverAdapter adapter.ServerAdapter) error {
+	templates, err := serverAdapter.ListResourceTemplates(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(templates) == 0 {
+		fmt.Println("No resource templates available")
+		return nil
+	}
+
+	fmt.Printf("Available resource templates (%d):\n", len(templates))
+	for i, template := range templates {
+		fmt.Printf("%d. %s (%s) - %s\n", i+1, template.Name, templateURI(template), template.Description)
+		fmt.Printf("   Usage: %s\n", templateUsage(template))
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandResourceTemplate(t *testing.T) {
+	profile := mcp.NewResourceTemplate("users://{id}/profile", "user-profile")
+	search := mcp.NewResourceTemplate("docs://search{?tag,q}", "search")
+
+	tests := []struct {
+		name     string
+		template mcp.ResourceTemplate
+		pairs    []string
+		want     string
+		wantErr  string
+	}{
+		{name: "simple", template: profile, pairs: []string{"id=42"}, want: "users://42/profile"},
+		{name: "encoded", template: profile, pairs: []string{"id=a b/c"}, want: "users://a%20b%2Fc/profile"},
+		{name: "query", template: search, pairs: []string{"q=mcp"}, want: "docs://search?q=mcp"},
+		{name: "list", template: search, pairs: []string{"tag=a", "tag=b"}, want: "docs://search?tag=a,b"},
+		{name: "undefined", template: profile, want: "users:///profile"},
+		{name: "unknown variable", template: profile, pairs: []string{"name=x"}, wantErr: "unknown variable name for resource template user-profile (variables: id)"},
+		{name: "invalid pair", template: profile, pairs: []string{"42"}, wantErr: `invalid variable "42", expected key=value`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uri, err := expandResourceTemplate(tt.template, tt.pairs)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, uri)
+		})
+	}
+}
+
+func TestTemplateUsage(t *testing.T) {
+	template := mcp.NewResourceTemplate("repo://{owner}/{repo}/issues{?state}", "issues")
+	assert.Equal(t, "read issues owner=<owner> repo=<repo> state=<state>", templateUsage(template))
+}
+
+func TestResolveResourceURI(t *testing.T) {
+	mcpServer := server.NewMCPServer("templates-test", "1.0.0")
+	mcpServer.AddResourceTemplate(mcp.NewResourceTemplate("users://{id}/profile", "user-profile"), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return nil, nil
+	})
+	testServer := server.NewTestStreamableHTTPServer(mcpServer)
+	defer testServer.Close()
+
+	ctx := context.Background()
+	serverAdapter, err := adapter.NewHTTPAdapter(adapter.Config{ServerURL: testServer.URL + "/mcp", Timeout: 5 * time.Second})
+	require.NoError(t, err)
+	require.NoError(t, serverAdapter.Connect(ctx))
+	defer func() { _ = serverAdapter.Disconnect() }()
+
+	tests := []struct {
+		name    string
+		arg     string
+		pairs   []string
+		want    string
+		wantErr string
+	}{
+		{name: "uri", arg: "file:///config.json", want: "file:///config.json"},
+		{name: "template name", arg: "user-profile", pairs: []string{"id=7"}, want: "users://7/profile"},
+		{name: "uri template", arg: "users://{id}/profile", pairs: []string{"id=7"}, want: "users://7/profile"},
+		{name: "unknown name", arg: "config", want: "config"},
+		{name: "unknown template", arg: "missing", pairs: []string{"id=7"}, wantErr: "unknown resource template: missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uri, err := resolveResourceURI(ctx, serverAdapter, tt.arg, tt.pairs)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, uri)
+		})
+	}
+}
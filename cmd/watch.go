@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jbovet/mcp-cli/pkg/adapter"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxDiffCells bounds the size of the table computed by lineDiff, above which
+// changed content is shown in full instead of as a diff
+const maxDiffCells = 4_000_000
+
+// diffContext is the number of unchanged lines shown around changes
+const diffContext = 2
+
+// resourceWatches routes notifications/resources/updated to the watch
+// commands waiting for them
+type resourceWatches struct {
+	mu       sync.Mutex
+	watching map[string]chan struct{}
+}
+
+var watches = &resourceWatches{watching: make(map[string]chan struct{})}
+
+// add starts routing the updates of uri to the returned channel. Updates
+// arriving while the previous one is handled are coalesced.
+func (r *resourceWatches) add(uri string) <-chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	updates := make(chan struct{}, 1)
+	r.watching[uri] = updates
+	return updates
+}
+
+func (r *resourceWatches) remove(uri string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.watching, uri)
+}
+
+// handle routes a resource update to its watch and reports whether it was
+// watched
+func (r *resourceWatches) handle(notification mcp.JSONRPCNotification) bool {
+	if notification.Method != mcp.MethodNotificationResourceUpdated {
+		return false
+	}
+	uri, _ := notification.Params.AdditionalFields["uri"].(string)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	updates, ok := r.watching[uri]
+	if !ok {
+		return false
+	}
+	select {
+	case updates <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// watchResourceInteractive subscribes to a resource and shows how its content
+// changes on each update until interrupted with Ctrl+C
+func watchResourceInteractive(ctx context.Context, serverAdapter adapter.ServerAdapter, arg string, pairs []string) error {
+	uri, err := resolveResourceURI(ctx, serverAdapter, arg, pairs)
+	if err != nil {
+		return err
+	}
+
+	updates := watches.add(uri)
+	defer watches.remove(uri)
+
+	if err := serverAdapter.Subscribe(ctx, uri); err != nil {
+		return err
+	}
+	defer func() {
+		if err := serverAdapter.Unsubscribe(ctx, uri); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+	}()
+
+	previous, err := readResourceText(ctx, serverAdapter, uri)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Watching %s (press Ctrl+C to stop)\n", uri)
+	if err := writeIndented(os.Stdout, previous); err != nil {
+		return err
+	}
+
+	interrupted, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	for {
+		select {
+		case <-interrupted.Done():
+			fmt.Printf("\nStopped watching %s\n", uri)
+			return nil
+		case <-updates:
+		}
+
+		current, err := readResourceText(ctx, serverAdapter, uri)
+		if err != nil {
+			fmt.Printf("Error reading resource %s: %v\n", uri, err)
+			continue
+		}
+		fmt.Printf("\n%s Resource updated: %s\n", time.Now().Format("15:04:05"), uri)
+		if err := writeDiff(os.Stdout, previous, current); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+		previous = current
+	}
+}
+
+// readResourceText reads a resource as text to compare its versions. Binary
+// contents are represented by their size and digest.
+func readResourceText(ctx context.Context, serverAdapter adapter.ServerAdapter, uri string) (string, error) {
+	result, err := serverAdapter.ReadResource(ctx, uri)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for _, content := range result.Contents {
+		switch c := content.(type) {
+		case mcp.TextResourceContents:
+			b.WriteString(c.Text)
+			if !strings.HasSuffix(c.Text, "\n") {
+				b.WriteString("\n")
+			}
+		case mcp.BlobResourceContents:
+			data, err := base64.StdEncoding.DecodeString(c.Blob)
+			if err != nil {
+				return "", fmt.Errorf("failed to decode blob content of %s: %w", c.URI, err)
+			}
+			fmt.Fprintf(&b, "[binary %s%s, %s, sha256 %x]\n", c.URI, mimeSuffix(c.MIMEType), formatBytes(len(data)), sha256.Sum256(data))
+		default:
+			fmt.Fprintf(&b, "%+v\n", c)
+		}
+	}
+	return b.String(), nil
+}
+
+// writeDiff writes the changed lines between two versions of a text, removed
+// lines prefixed with "-" and added lines with "+", with a few unchanged
+// lines around them
+func writeDiff(w io.Writer, before, after string) error {
+	if before == after {
+		_, err := fmt.Fprintln(w, "  (no changes)")
+		return err
+	}
+
+	oldLines := splitLines(before)
+	newLines := splitLines(after)
+	if len(oldLines)*len(newLines) > maxDiffCells {
+		if _, err := fmt.Fprintf(w, "  (content too large to diff, %d lines)\n", len(newLines)); err != nil {
+			return err
+		}
+		return writeIndented(w, after)
+	}
+
+	edits := lineDiff(oldLines, newLines)
+	lastShown := -1
+	for i, edit := range edits {
+		if edit.op == ' ' && !nearChange(edits, i) {
+			continue
+		}
+		if lastShown >= 0 && i > lastShown+1 {
+			if _, err := fmt.Fprintln(w, "  ..."); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%c %s\n", edit.op, edit.line); err != nil {
+			return err
+		}
+		lastShown = i
+	}
+	return nil
+}
+
+// nearChange reports whether an unchanged line is within diffContext lines
+// of a change
+func nearChange(edits []lineEdit, i int) bool {
+	for j := max(0, i-diffContext); j <= min(len(edits)-1, i+diffContext); j++ {
+		if edits[j].op != ' ' {
+			return true
+		}
+	}
+	return false
+}
+
+// lineEdit is a line of a diff: ' ' when unchanged, '-' when removed and '+'
+// when added
+type lineEdit struct {
+	op   byte
+	line string
+}
+
+// lineDiff computes the edits turning a into b from their longest common
+// subsequence of lines
+func lineDiff(a, b []string) []lineEdit {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var edits []lineEdit
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			edits = append(edits, lineEdit{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			edits = append(edits, lineEdit{'-', a[i]})
+			i++
+		default:
+			edits = append(edits, lineEdit{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		edits = append(edits, lineEdit{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		edits = append(edits, lineEdit{'+', b[j]})
+	}
+	return edits
+}
+
+func splitLines(text string) []string {
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   string
+	}{
+		{
+			name:   "unchanged",
+			before: "a\nb\n",
+			after:  "a\nb\n",
+			want:   "  (no changes)\n",
+		},
+		{
+			name:   "changed line",
+			before: "status: running\nstep: 1\n",
+			after:  "status: passed\nstep: 1\n",
+			want:   "- status: running\n+ status: passed\n  step: 1\n",
+		},
+		{
+			name:   "appended lines",
+			before: "1\n2\n3\n4\n5\n",
+			after:  "1\n2\n3\n4\n5\n6\n7\n",
+			want:   "  4\n  5\n+ 6\n+ 7\n",
+		},
+		{
+			name:   "distant changes",
+			before: "a\n1\n2\n3\n4\n5\n6\nb\n",
+			after:  "A\n1\n2\n3\n4\n5\n6\nB\n",
+			want:   "- a\n+ A\n  1\n  2\n  ...\n  5\n  6\n- b\n+ B\n",
+		},
+		{
+			name:   "from empty",
+			before: "",
+			after:  "first\n",
+			want:   "+ first\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, writeDiff(&out, tt.before, tt.after))
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestWriteDiffTooLarge(t *testing.T) {
+	before := strings.Repeat("x\n", 3000)
+	after := strings.Repeat("y\n", 3000)
+
+	var out bytes.Buffer
+	require.NoError(t, writeDiff(&out, before, after))
+	assert.True(t, strings.HasPrefix(out.String(), "  (content too large to diff, 3000 lines)\n  y\n"))
+}
+
+func TestResourceWatches(t *testing.T) {
+	r := &resourceWatches{watching: make(map[string]chan struct{})}
+	updates := r.add("file:///status")
+
+	updated := func(uri string) mcp.JSONRPCNotification {
+		return testNotification(mcp.MethodNotificationResourceUpdated, map[string]any{"uri": uri})
+	}
+
+	assert.False(t, r.handle(updated("file:///other")))
+	assert.False(t, r.handle(testNotification(mcp.MethodNotificationToolsListChanged, nil)))
+
+	// Updates arriving before the previous one is handled are coalesced
+	assert.True(t, r.handle(updated("file:///status")))
+	assert.True(t, r.handle(updated("file:///status")))
+	assert.Len(t, updates, 1)
+
+	r.remove("file:///status")
+	assert.False(t, r.handle(updated("file:///status")))
+}
@@ -4,8 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/jbovet/mcp-cli/pkg/oauth"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -30,17 +36,51 @@ type ServerAdapter interface {
 	// ListResources returns available resources from the server
 	ListResources(ctx context.Context) ([]mcp.Resource, error)
 
+	// ListResourceTemplates returns available resource templates from the server
+	ListResourceTemplates(ctx context.Context) ([]mcp.ResourceTemplate, error)
+
 	// ReadResource reads a specific resource
 	ReadResource(ctx context.Context, uri string) (*mcp.ReadResourceResult, error)
 
+	// Subscribe asks the server to send notifications/resources/updated
+	// when the resource changes
+	Subscribe(ctx context.Context, uri string) error
+
+	// Unsubscribe cancels a subscription made with Subscribe
+	Unsubscribe(ctx context.Context, uri string) error
+
 	// ListPrompts returns available prompts from the server
 	ListPrompts(ctx context.Context) ([]mcp.Prompt, error)
 
 	// GetPrompt retrieves a specific prompt
 	GetPrompt(ctx context.Context, name string, arguments map[string]string) (*mcp.GetPromptResult, error)
 
+	// Complete asks the server for the values completing an argument of a
+	// prompt or a variable of a resource template, given as a
+	// mcp.PromptReference or mcp.ResourceReference. Arguments holds the
+	// values of the other arguments already given.
+	Complete(ctx context.Context, ref any, argument mcp.CompleteArgument, arguments map[string]string) (*mcp.Completion, error)
+
 	// IsConnected returns whether the adapter is currently connected
 	IsConnected() bool
+
+	// Pager streams the listings page by page
+	Pager
+
+	// Notifier delivers server notifications
+	Notifier
+
+	// Sampler answers server sampling requests
+	Sampler
+
+	// RootsProvider offers filesystem roots to the server
+	RootsProvider
+
+	// Elicitor answers server elicitation requests
+	Elicitor
+
+	// ConnectionMonitor tells when the connection is lost
+	ConnectionMonitor
 }
 
 // Config holds configuration for server adapters
@@ -48,16 +88,39 @@ type Config struct {
 	// ServerURL for HTTP-based connections
 	ServerURL string
 
+	// Headers sent with every request of HTTP-based connections. Values can
+	// reference environment variables as ${NAME}.
+	Headers map[string]string
+
+	// OAuth enables the OAuth authorization flow for HTTP servers rejecting
+	// requests with 401
+	OAuth *oauth.Options
+
 	// Command and arguments for stdio-based connections
 	Command string
 	Args    []string
 	Env     []string
 
+	// ExpandEnv expands the ${NAME} references of Args and of the values of
+	// Env, given as NAME=value. It is set for the servers of the
+	// configuration file, whose values cannot be expanded by a shell.
+	// References to unset variables are kept.
+	ExpandEnv bool
+
+	// StderrLog is a file the stderr output of stdio servers is appended to
+	StderrLog string
+
 	// Connection timeout
 	Timeout time.Duration
 
+	// Pagination limits how far listings follow cursors
+	Pagination Pagination
+
 	// Verbose logging
 	Verbose bool
+
+	// Secrets are masked in log output
+	Secrets []string
 }
 
 // AdapterType represents the type of adapter
@@ -67,6 +130,8 @@ const (
 	AdapterTypeStdio      AdapterType = "stdio"
 	AdapterTypeHTTP       AdapterType = "http"
 	AdapterTypeStreamable AdapterType = "streamable"
+	AdapterTypeSSE        AdapterType = "sse"
+	AdapterTypeAuto       AdapterType = "auto"
 )
 
 // NewAdapter creates a new server adapter based on the configuration
@@ -76,6 +141,10 @@ func NewAdapter(adapterType AdapterType, config Config) (ServerAdapter, error) {
 		return NewStdioAdapter(config)
 	case AdapterTypeHTTP, AdapterTypeStreamable:
 		return NewHTTPAdapter(config)
+	case AdapterTypeSSE:
+		return NewSSEAdapter(config)
+	case AdapterTypeAuto:
+		return NewAutoAdapter(config)
 	default:
 		return nil, fmt.Errorf("unsupported adapter type: %s", adapterType)
 	}
@@ -86,6 +155,18 @@ type BaseAdapter struct {
 	config     Config
 	connected  bool
 	serverInfo *mcp.Implementation
+
+	notifyMu sync.RWMutex
+	handlers []NotificationHandler
+
+	transport      *trackingTransport
+	progressTokens atomic.Int64
+
+	sampling    SamplingHandler
+	roots       *Roots
+	elicitation ElicitationHandler
+
+	connection connectionState
 }
 
 func (b *BaseAdapter) IsConnected() bool {
@@ -107,8 +188,102 @@ func (b *BaseAdapter) setServerInfo(info *mcp.Implementation) {
 	b.serverInfo = info
 }
 
+// httpHeaders returns the configured headers with environment variable
+// references expanded
+func (c Config) httpHeaders() (map[string]string, error) {
+	headers := make(map[string]string, len(c.Headers))
+	for name, value := range c.Headers {
+		expanded, err := expandEnv(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for header %s: %w", name, err)
+		}
+		headers[name] = expanded
+	}
+	return headers, nil
+}
+
+// commandLine returns the arguments and environment of the command, with
+// environment variable references expanded when ExpandEnv is set
+func (c Config) commandLine() ([]string, []string) {
+	if !c.ExpandEnv {
+		return c.Args, c.Env
+	}
+
+	args := make([]string, 0, len(c.Args))
+	for _, arg := range c.Args {
+		args = append(args, expandSetEnv(arg))
+	}
+
+	env := make([]string, 0, len(c.Env))
+	for _, variable := range c.Env {
+		// A name without a value is passed on as is
+		if name, value, found := strings.Cut(variable, "="); found {
+			variable = name + "=" + expandSetEnv(value)
+		}
+		env = append(env, variable)
+	}
+	return args, env
+}
+
+// envReference matches ${NAME} environment variable references
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnv replaces ${NAME} references with the value of the environment
+// variable. Unlike os.ExpandEnv, bare $NAME is left alone so values such as
+// tokens may contain '$', and unset variables are reported as errors.
+func expandEnv(value string) (string, error) {
+	var missing []string
+	expanded := envReference.ReplaceAllStringFunc(value, func(reference string) string {
+		name := envReference.FindStringSubmatch(reference)[1]
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+		return v
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("environment variable %s is not set", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
+
+// expandSetEnv replaces the ${NAME} references to set environment variables
+// with their value, keeping the other ones
+func expandSetEnv(value string) string {
+	return envReference.ReplaceAllStringFunc(value, func(reference string) string {
+		if v, ok := os.LookupEnv(envReference.FindStringSubmatch(reference)[1]); ok {
+			return v
+		}
+		return reference
+	})
+}
+
 func (b *BaseAdapter) logf(format string, args ...any) {
 	if b.config.Verbose {
-		log.Printf(format, args...)
+		log.Print(b.config.mask(fmt.Sprintf(format, args...)))
+	}
+}
+
+// transportLogger passes the log messages of the mcp-go transports to logf,
+// so they are only shown in verbose mode
+type transportLogger struct {
+	adapter *BaseAdapter
+}
+
+func (l transportLogger) Infof(format string, args ...any) {
+	l.adapter.logf(format, args...)
+}
+
+func (l transportLogger) Errorf(format string, args ...any) {
+	l.adapter.logf("Warning: "+format, args...)
+}
+
+// mask hides the configured secrets in s
+func (c Config) mask(s string) string {
+	for _, secret := range c.Secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, "****")
+		}
 	}
+	return s
 }
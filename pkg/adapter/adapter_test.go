@@ -2,9 +2,13 @@ package adapter
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -18,6 +22,9 @@ func TestAdapterFactory(t *testing.T) {
 			"command": "echo",
 			"args":    []string{"hello"},
 			"verbose": true,
+
+			"expand_env": true,
+			"pagination": Pagination{MaxPages: 5, MaxItems: 50},
 		}
 
 		adapter, err := factory.CreateFromConfig(config)
@@ -29,6 +36,9 @@ func TestAdapterFactory(t *testing.T) {
 		assert.Equal(t, "echo", stdioAdapter.config.Command)
 		assert.Equal(t, []string{"hello"}, stdioAdapter.config.Args)
 		assert.True(t, stdioAdapter.config.Verbose)
+		assert.True(t, stdioAdapter.config.ExpandEnv)
+		assert.Equal(t, 5, stdioAdapter.config.Pagination.maxPages())
+		assert.Equal(t, 50, stdioAdapter.config.Pagination.maxItems())
 	})
 
 	t.Run("CreateHTTPAdapter", func(t *testing.T) {
@@ -46,14 +56,30 @@ func TestAdapterFactory(t *testing.T) {
 		assert.Equal(t, "http://localhost:8080/mcp", httpAdapter.config.ServerURL)
 	})
 
+	t.Run("CreateSSEAdapter", func(t *testing.T) {
+		config := map[string]interface{}{
+			"type": "sse",
+			"url":  "http://localhost:8080/sse",
+		}
+
+		adapter, err := factory.CreateFromConfig(config)
+		require.NoError(t, err)
+		assert.NotNil(t, adapter)
+
+		sseAdapter, ok := adapter.(*SSEAdapter)
+		assert.True(t, ok)
+		assert.Equal(t, "http://localhost:8080/sse", sseAdapter.config.ServerURL)
+	})
+
 	t.Run("CreateFromURL_HTTP", func(t *testing.T) {
 		adapter, err := factory.CreateFromURL("http://localhost:8080/mcp", false)
 		require.NoError(t, err)
 		assert.NotNil(t, adapter)
 
-		httpAdapter, ok := adapter.(*HTTPAdapter)
+		autoAdapter, ok := adapter.(*AutoAdapter)
 		assert.True(t, ok)
-		assert.Equal(t, "http://localhost:8080/mcp", httpAdapter.config.ServerURL)
+		assert.Equal(t, "http://localhost:8080/mcp", autoAdapter.config.ServerURL)
+		assert.False(t, autoAdapter.IsConnected())
 	})
 
 	t.Run("CreateFromURL_Command", func(t *testing.T) {
@@ -104,10 +130,12 @@ func TestAdapterFactory(t *testing.T) {
 
 	t.Run("GetSupportedTypes", func(t *testing.T) {
 		types := factory.GetSupportedTypes()
-		assert.Len(t, types, 3)
+		assert.Len(t, types, 5)
 		assert.Contains(t, types, AdapterTypeStdio)
 		assert.Contains(t, types, AdapterTypeHTTP)
 		assert.Contains(t, types, AdapterTypeStreamable)
+		assert.Contains(t, types, AdapterTypeSSE)
+		assert.Contains(t, types, AdapterTypeAuto)
 	})
 }
 
@@ -216,6 +244,231 @@ func TestHTTPAdapter(t *testing.T) {
 	})
 }
 
+func TestSSEAdapter(t *testing.T) {
+	t.Run("NewSSEAdapter_MissingURL", func(t *testing.T) {
+		_, err := NewSSEAdapter(Config{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "server URL is required")
+	})
+
+	t.Run("ConnectAndCallTool", func(t *testing.T) {
+		var protocolVersion string
+		hooks := &server.Hooks{}
+		hooks.AddBeforeInitialize(func(ctx context.Context, id any, request *mcp.InitializeRequest) {
+			protocolVersion = request.Params.ProtocolVersion
+		})
+		mcpServer := server.NewMCPServer("sse-test", "1.0.0", server.WithHooks(hooks))
+		mcpServer.AddTool(
+			mcp.NewTool("echo", mcp.WithString("message", mcp.Required())),
+			func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return mcp.NewToolResultText(request.GetArguments()["message"].(string)), nil
+			},
+		)
+		testServer := server.NewTestServer(mcpServer)
+		defer testServer.Close()
+
+		adapter, err := NewSSEAdapter(Config{ServerURL: testServer.URL + "/sse", Timeout: 5 * time.Second})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		require.NoError(t, adapter.Connect(ctx))
+		defer func() {
+			assert.NoError(t, adapter.Disconnect())
+			assert.False(t, adapter.IsConnected())
+		}()
+
+		info, err := adapter.GetServerInfo()
+		require.NoError(t, err)
+		assert.Equal(t, "sse-test", info.Name)
+		assert.Equal(t, "2024-11-05", protocolVersion)
+
+		tools, err := adapter.ListTools(ctx)
+		require.NoError(t, err)
+		require.Len(t, tools, 1)
+		assert.Equal(t, "echo", tools[0].Name)
+
+		result, err := adapter.CallTool(ctx, "echo", map[string]any{"message": "hello"})
+		require.NoError(t, err)
+		require.Len(t, result.Content, 1)
+		assert.Equal(t, "hello", result.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("Connect_InvalidURL", func(t *testing.T) {
+		adapter, err := NewSSEAdapter(Config{ServerURL: "http://localhost:9999/sse", Timeout: time.Second})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		err = adapter.Connect(ctx)
+		assert.Error(t, err)
+		assert.False(t, adapter.IsConnected())
+	})
+}
+
+func TestConfigHTTPHeaders(t *testing.T) {
+	t.Setenv("MCP_CLI_TEST_TOKEN", "secret")
+
+	config := Config{Headers: map[string]string{
+		"Authorization": "Bearer ${MCP_CLI_TEST_TOKEN}",
+		"X-Literal":     "pa$$word",
+	}}
+	headers, err := config.httpHeaders()
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer secret", headers["Authorization"])
+	assert.Equal(t, "pa$$word", headers["X-Literal"])
+
+	config.Headers = map[string]string{"X-API-Key": "${MCP_CLI_TEST_UNSET}"}
+	_, err = config.httpHeaders()
+	assert.ErrorContains(t, err, "environment variable MCP_CLI_TEST_UNSET is not set")
+}
+
+func TestConfigCommandLine(t *testing.T) {
+	t.Setenv("MCP_CLI_TEST_TOKEN", "secret")
+
+	config := Config{
+		Args: []string{"--token", "${MCP_CLI_TEST_TOKEN}", "$HOME", "--template", "${MCP_CLI_TEST_UNSET}/x"},
+		Env:  []string{"API_KEY=${MCP_CLI_TEST_TOKEN}", "MODE=a=b", "PATH", "EMPTY="},
+	}
+
+	// Values given on the command line are passed as is
+	args, env := config.commandLine()
+	assert.Equal(t, config.Args, args)
+	assert.Equal(t, config.Env, env)
+
+	// References to unset variables and names without a value are kept
+	config.ExpandEnv = true
+	args, env = config.commandLine()
+	assert.Equal(t, []string{"--token", "secret", "$HOME", "--template", "${MCP_CLI_TEST_UNSET}/x"}, args)
+	assert.Equal(t, []string{"API_KEY=secret", "MODE=a=b", "PATH", "EMPTY="}, env)
+}
+
+func TestHTTPAdapterHeaders(t *testing.T) {
+	t.Setenv("MCP_CLI_TEST_TOKEN", "secret")
+
+	mcpServer := server.NewMCPServer("auth-test", "1.0.0")
+	requireToken := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	t.Run("StreamableHTTP", func(t *testing.T) {
+		testServer := httptest.NewServer(requireToken(server.NewStreamableHTTPServer(mcpServer)))
+		defer testServer.Close()
+
+		for _, headers := range []map[string]string{nil, {"Authorization": "Bearer ${MCP_CLI_TEST_TOKEN}"}} {
+			adapter, err := NewHTTPAdapter(Config{ServerURL: testServer.URL, Headers: headers, Timeout: 5 * time.Second})
+			require.NoError(t, err)
+
+			err = adapter.Connect(context.Background())
+			if headers == nil {
+				assert.ErrorContains(t, err, "401")
+				continue
+			}
+			require.NoError(t, err)
+			assert.NoError(t, adapter.Disconnect())
+		}
+	})
+
+	t.Run("SSE", func(t *testing.T) {
+		sseServer := server.NewSSEServer(mcpServer)
+		testServer := httptest.NewServer(requireToken(sseServer))
+		defer testServer.Close()
+
+		adapter, err := NewSSEAdapter(Config{
+			ServerURL: testServer.URL + "/sse",
+			Headers:   map[string]string{"Authorization": "Bearer ${MCP_CLI_TEST_TOKEN}"},
+			Timeout:   5 * time.Second,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, adapter.Connect(context.Background()))
+		assert.NoError(t, adapter.Disconnect())
+	})
+}
+
+func TestAutoAdapter(t *testing.T) {
+	mcpServer := server.NewMCPServer("auto-test", "1.0.0")
+	mcpServer.AddTool(mcp.NewTool("ping"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("pong"), nil
+	})
+
+	tests := []struct {
+		name       string
+		testServer func() *httptest.Server
+		path       string
+		expected   AdapterType
+	}{
+		{
+			name:       "StreamableHTTP",
+			testServer: func() *httptest.Server { return server.NewTestStreamableHTTPServer(mcpServer) },
+			path:       "/mcp",
+			expected:   AdapterTypeStreamable,
+		},
+		{
+			name:       "FallbackToSSE",
+			testServer: func() *httptest.Server { return server.NewTestServer(mcpServer) },
+			path:       "/sse",
+			expected:   AdapterTypeSSE,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testServer := test.testServer()
+			defer testServer.Close()
+
+			adapter, err := NewAutoAdapter(Config{ServerURL: testServer.URL + test.path, Timeout: 5 * time.Second})
+			require.NoError(t, err)
+			assert.Empty(t, adapter.NegotiatedTransport())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			require.NoError(t, adapter.Connect(ctx))
+			assert.True(t, adapter.IsConnected())
+			assert.Equal(t, test.expected, adapter.NegotiatedTransport())
+
+			result, err := adapter.CallTool(ctx, "ping", nil)
+			require.NoError(t, err)
+			assert.Equal(t, "pong", result.Content[0].(mcp.TextContent).Text)
+
+			require.NoError(t, adapter.Disconnect())
+			assert.False(t, adapter.IsConnected())
+		})
+	}
+
+	t.Run("ServerError", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer testServer.Close()
+
+		adapter, err := NewAutoAdapter(Config{ServerURL: testServer.URL, Timeout: time.Second})
+		require.NoError(t, err)
+
+		err = adapter.Connect(context.Background())
+		assert.ErrorContains(t, err, "unexpected status 500")
+		assert.False(t, adapter.IsConnected())
+	})
+
+	t.Run("NotConnected", func(t *testing.T) {
+		adapter, err := NewAutoAdapter(Config{ServerURL: "http://localhost:9999/mcp"})
+		require.NoError(t, err)
+
+		_, err = adapter.ListTools(context.Background())
+		assert.ErrorContains(t, err, "not connected")
+		assert.NoError(t, adapter.Disconnect())
+	})
+}
+
 // Integration test helpers
 func TestAdapterIntegration(t *testing.T) {
 	// Skip integration tests in CI unless specifically enabled
@@ -250,3 +503,9 @@ func TestAdapterIntegration(t *testing.T) {
 		}
 	})
 }
+
+func TestConfigMask(t *testing.T) {
+	config := Config{Secrets: []string{"s3cr3t", ""}}
+	assert.Equal(t, "API_KEY=**** --token ****", config.mask("API_KEY=s3cr3t --token s3cr3t"))
+	assert.Equal(t, "nothing secret", Config{}.mask("nothing secret"))
+}
@@ -0,0 +1,240 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// AutoAdapter implements ServerAdapter for HTTP servers whose transport is
+// not known in advance. It follows the backwards compatibility procedure of
+// the MCP specification: an InitializeRequest is POSTed to the server URL and
+// the streamable HTTP transport is used when it is accepted. When the server
+// answers with a 4xx status, the URL is treated as a legacy SSE endpoint.
+//
+// Once connected, all requests are delegated to the negotiated adapter.
+type AutoAdapter struct {
+	ServerAdapter
+	config      Config
+	negotiated  AdapterType
+	handlers    []NotificationHandler
+	sampling    SamplingHandler
+	roots       *Roots
+	elicitation ElicitationHandler
+}
+
+// NewAutoAdapter creates a new transport detecting adapter
+func NewAutoAdapter(config Config) (*AutoAdapter, error) {
+	if config.ServerURL == "" {
+		return nil, fmt.Errorf("server URL is required for auto adapter")
+	}
+
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	// Until a transport is negotiated, requests are answered by an
+	// unconnected HTTP adapter
+	httpAdapter, err := NewHTTPAdapter(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AutoAdapter{
+		ServerAdapter: httpAdapter,
+		config:        config,
+	}, nil
+}
+
+// Connect detects the transport supported by the server and connects using it
+func (a *AutoAdapter) Connect(ctx context.Context) error {
+	if a.IsConnected() {
+		return fmt.Errorf("already connected")
+	}
+
+	adapterType, err := a.detectTransport(ctx)
+	if err != nil {
+		return err
+	}
+
+	var serverAdapter ServerAdapter
+	if adapterType == AdapterTypeSSE {
+		serverAdapter, err = NewSSEAdapter(a.config)
+	} else {
+		serverAdapter, err = NewHTTPAdapter(a.config)
+	}
+	if err != nil {
+		return err
+	}
+	for _, handler := range a.handlers {
+		serverAdapter.OnNotification(handler)
+	}
+	if a.sampling != nil {
+		serverAdapter.SetSamplingHandler(a.sampling)
+	}
+	if a.roots != nil {
+		serverAdapter.SetRoots(a.roots)
+	}
+	if a.elicitation != nil {
+		serverAdapter.SetElicitationHandler(a.elicitation)
+	}
+
+	if err := serverAdapter.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect using %s transport: %w", adapterType, err)
+	}
+
+	a.ServerAdapter = serverAdapter
+	a.negotiated = adapterType
+	return nil
+}
+
+// Disconnect closes the connection of the negotiated adapter
+func (a *AutoAdapter) Disconnect() error {
+	if !a.IsConnected() {
+		return nil
+	}
+
+	err := a.ServerAdapter.Disconnect()
+	a.negotiated = ""
+	return err
+}
+
+// OnNotification registers a handler for server notifications, which is
+// passed on to the adapter of the negotiated transport
+func (a *AutoAdapter) OnNotification(handler NotificationHandler) {
+	a.handlers = append(a.handlers, handler)
+	a.ServerAdapter.OnNotification(handler)
+}
+
+// SetSamplingHandler sets the handler of sampling requests, which is passed
+// on to the adapter of the negotiated transport
+func (a *AutoAdapter) SetSamplingHandler(handler SamplingHandler) {
+	a.sampling = handler
+	a.ServerAdapter.SetSamplingHandler(handler)
+}
+
+// SetRoots sets the roots listed to the server, which are passed on to the
+// adapter of the negotiated transport
+func (a *AutoAdapter) SetRoots(roots *Roots) {
+	a.roots = roots
+	a.ServerAdapter.SetRoots(roots)
+}
+
+// SetElicitationHandler sets the handler of elicitation requests, which is
+// passed on to the adapter of the negotiated transport
+func (a *AutoAdapter) SetElicitationHandler(handler ElicitationHandler) {
+	a.elicitation = handler
+	a.ServerAdapter.SetElicitationHandler(handler)
+}
+
+// NegotiatedTransport returns the transport selected by Connect, or an empty
+// type when not connected
+func (a *AutoAdapter) NegotiatedTransport() AdapterType {
+	return a.negotiated
+}
+
+// detectTransport POSTs an InitializeRequest to the server URL. A successful
+// response selects the streamable HTTP transport and a 4xx status selects the
+// legacy SSE transport.
+//
+// 401 is not treated as a fallback signal: it means the endpoint exists but
+// requires authorization, which is handled by the streamable HTTP transport.
+func (a *AutoAdapter) detectTransport(ctx context.Context) (AdapterType, error) {
+	a.logf("Detecting transport of MCP server: %s", a.config.ServerURL)
+
+	headers, err := a.config.httpHeaders()
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, a.config.Timeout)
+	defer cancel()
+
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{
+		Name:    "mcp-cli-adapter",
+		Version: "1.0.0",
+	}
+	body, err := json.Marshal(map[string]any{
+		"jsonrpc": mcp.JSONRPC_VERSION,
+		"id":      0,
+		"method":  string(mcp.MethodInitialize),
+		"params":  initRequest.Params,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal initialize request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.config.ServerURL, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to detect transport: %w", err)
+	}
+	// The response may be an event stream, only the status is needed
+	_ = resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		a.logf("Server accepted streamable HTTP initialize request")
+		a.endProbeSession(headers, resp.Header.Get("Mcp-Session-Id"))
+		return AdapterTypeStreamable, nil
+	case resp.StatusCode == http.StatusUnauthorized:
+		return AdapterTypeStreamable, nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		a.logf("Server rejected streamable HTTP initialize request (status %d), falling back to SSE", resp.StatusCode)
+		return AdapterTypeSSE, nil
+	default:
+		return "", fmt.Errorf("failed to detect transport: unexpected status %d", resp.StatusCode)
+	}
+}
+
+// endProbeSession terminates the session created by the detection request
+// so the server can release it. Failures are ignored: servers are allowed to
+// reject session termination.
+func (a *AutoAdapter) endProbeSession(headers map[string]string, sessionID string) {
+	if sessionID == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, a.config.ServerURL, nil)
+	if err != nil {
+		return
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Mcp-Session-Id", sessionID)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
+
+func (a *AutoAdapter) logf(format string, args ...any) {
+	if a.config.Verbose {
+		log.Print(a.config.mask(fmt.Sprintf(format, args...)))
+	}
+}
@@ -0,0 +1,160 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// cancelTimeout bounds the time spent telling the server that a request was
+// cancelled, as the request context is already done at that point
+const cancelTimeout = 5 * time.Second
+
+// trackingTransport records the IDs of the requests sent with a context
+// created by trackRequests. The client assigns them internally, but they are
+// needed to cancel a request on the server.
+type trackingTransport struct {
+	transport.Interface
+}
+
+// SendRequest records the request ID and sends the request
+func (t *trackingTransport) SendRequest(ctx context.Context, request transport.JSONRPCRequest) (*transport.JSONRPCResponse, error) {
+	if ids, ok := ctx.Value(requestIDsKey{}).(*requestIDs); ok {
+		ids.add(request.ID)
+	}
+	return t.Interface.SendRequest(ctx, request)
+}
+
+// SetRequestHandler passes the handler of server requests, such as
+// sampling, to transports supporting them
+func (t *trackingTransport) SetRequestHandler(handler transport.RequestHandler) {
+	if bidirectional, ok := t.Interface.(transport.BidirectionalInterface); ok {
+		bidirectional.SetRequestHandler(handler)
+	}
+}
+
+// SetProtocolVersion passes the negotiated protocol version to HTTP
+// transports, which send it in a header
+func (t *trackingTransport) SetProtocolVersion(version string) {
+	if conn, ok := t.Interface.(transport.HTTPConnection); ok {
+		conn.SetProtocolVersion(version)
+	}
+}
+
+type requestIDsKey struct{}
+
+// requestIDs collects the IDs of the requests sent with a context
+type requestIDs struct {
+	mu  sync.Mutex
+	ids []mcp.RequestId
+}
+
+// trackRequests returns a context recording the IDs of the requests sent
+// with it
+func trackRequests(ctx context.Context) (context.Context, *requestIDs) {
+	ids := &requestIDs{}
+	return context.WithValue(ctx, requestIDsKey{}, ids), ids
+}
+
+func (r *requestIDs) add(id mcp.RequestId) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ids = append(r.ids, id)
+}
+
+func (r *requestIDs) list() []mcp.RequestId {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]mcp.RequestId(nil), r.ids...)
+}
+
+// newClient creates a client on top of t, keeping the transport to track
+// the requests sent by the client. The client answers the server requests
+// for which a handler is set.
+func (b *BaseAdapter) newClient(t transport.Interface) *mcpclient.Client {
+	b.transport = &trackingTransport{Interface: t}
+	options := b.clientOptions()
+	// Transports without a request handler, such as SSE, cannot receive
+	// server requests, so the features answering them are not advertised
+	if _, ok := t.(transport.BidirectionalInterface); !ok && len(options) > 0 {
+		b.logf("Warning: the transport does not support server requests, sampling, roots and elicitation are disabled")
+		options = nil
+	}
+	return mcpclient.NewClient(b.transport, options...)
+}
+
+// progressTokenKey is the context key of the token set by WithProgressToken
+type progressTokenKey struct{}
+
+// WithProgressToken returns a context making CallTool send token as the
+// progress token of the request, so the notifications/progress of the call
+// can be told apart from those of other requests
+func WithProgressToken(ctx context.Context, token mcp.ProgressToken) context.Context {
+	return context.WithValue(ctx, progressTokenKey{}, token)
+}
+
+// callTool calls a tool with the given client. The request carries a progress
+// token for the server to report its progress with notifications/progress,
+// the one set with WithProgressToken or a new one. When ctx is done before
+// the result arrives, the server is told to stop with notifications/cancelled.
+func (b *BaseAdapter) callTool(ctx context.Context, client mcpclient.MCPClient, name string, arguments map[string]any) (*mcp.CallToolResult, error) {
+	if !b.connected {
+		return nil, fmt.Errorf("not connected to server")
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = arguments
+	token := ctx.Value(progressTokenKey{})
+	if token == nil {
+		token = b.progressTokens.Add(1)
+	}
+	request.Params.Meta = &mcp.Meta{ProgressToken: token}
+
+	tracked, ids := trackRequests(ctx)
+	result, err := client.CallTool(tracked, request)
+	if err != nil {
+		if ctx.Err() != nil {
+			b.cancelRequests(ids.list(), context.Cause(ctx))
+			return nil, fmt.Errorf("call to tool %s cancelled: %w", name, context.Cause(ctx))
+		}
+		return nil, fmt.Errorf("failed to call tool %s: %w", name, err)
+	}
+
+	return result, nil
+}
+
+// cancelRequests sends notifications/cancelled for requests whose result
+// will not be used
+func (b *BaseAdapter) cancelRequests(ids []mcp.RequestId, reason error) {
+	if b.transport == nil {
+		return
+	}
+
+	for _, id := range ids {
+		params := map[string]any{"requestId": id.Value()}
+		if reason != nil {
+			params["reason"] = reason.Error()
+		}
+		notification := mcp.JSONRPCNotification{
+			JSONRPC: mcp.JSONRPC_VERSION,
+			Notification: mcp.Notification{
+				Method: "notifications/cancelled",
+				Params: mcp.NotificationParams{AdditionalFields: params},
+			},
+		}
+
+		b.logf("Cancelling request %v", id.Value())
+		ctx, cancel := context.WithTimeout(context.Background(), cancelTimeout)
+		err := b.transport.SendNotification(ctx, notification)
+		cancel()
+		if err != nil {
+			b.logf("Warning: failed to cancel request %v: %v", id.Value(), err)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSlowServer creates a server whose "slow" tool reports its progress and
+// waits until it is cancelled, passing the parameters of
+// notifications/cancelled to cancelled
+func newSlowServer(cancelled chan<- map[string]any) *server.MCPServer {
+	mcpServer := server.NewMCPServer("slow-test", "1.0.0")
+	mcpServer.AddNotificationHandler("notifications/cancelled", func(ctx context.Context, notification mcp.JSONRPCNotification) {
+		cancelled <- notification.Params.AdditionalFields
+	})
+	mcpServer.AddTool(mcp.NewTool("slow"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+			return mcp.NewToolResultError("no progress token"), nil
+		}
+		if err := mcpServer.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+			"progressToken": request.Params.Meta.ProgressToken,
+			"progress":      1,
+			"total":         4,
+			"message":       "started",
+		}); err != nil {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(5 * time.Second):
+		}
+		return mcp.NewToolResultText("done"), nil
+	})
+	return mcpServer
+}
+
+func TestCallToolCancel(t *testing.T) {
+	tests := []struct {
+		name        string
+		adapterType AdapterType
+		testServer  func(*server.MCPServer) *httptest.Server
+		path        string
+	}{
+		{
+			name:        "SSE",
+			adapterType: AdapterTypeSSE,
+			testServer:  func(s *server.MCPServer) *httptest.Server { return server.NewTestServer(s) },
+			path:        "/sse",
+		},
+		{
+			name:        "StreamableHTTP",
+			adapterType: AdapterTypeStreamable,
+			testServer:  func(s *server.MCPServer) *httptest.Server { return server.NewTestStreamableHTTPServer(s) },
+			path:        "/mcp",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cancelled := make(chan map[string]any, 1)
+			testServer := test.testServer(newSlowServer(cancelled))
+			defer testServer.Close()
+
+			adapter, err := NewAdapter(test.adapterType, Config{ServerURL: testServer.URL + test.path, Timeout: 5 * time.Second})
+			require.NoError(t, err)
+
+			progress := make(chan map[string]any, 1)
+			adapter.OnNotification(func(notification mcp.JSONRPCNotification) {
+				if notification.Method == "notifications/progress" {
+					progress <- notification.Params.AdditionalFields
+				}
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			require.NoError(t, adapter.Connect(ctx))
+			defer func() { _ = adapter.Disconnect() }()
+
+			callCtx, cancelCall := context.WithCancelCause(ctx)
+			go func() {
+				select {
+				case params := <-progress:
+					assert.Equal(t, "started", params["message"])
+					assert.Equal(t, "call-1", params["progressToken"])
+				case <-ctx.Done():
+				}
+				cancelCall(errors.New("interrupted by user"))
+			}()
+
+			_, err = adapter.CallTool(WithProgressToken(callCtx, "call-1"), "slow", nil)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, "call to tool slow cancelled: interrupted by user")
+
+			select {
+			case params := <-cancelled:
+				assert.NotNil(t, params["requestId"])
+				assert.Equal(t, "interrupted by user", params["reason"])
+			case <-ctx.Done():
+				t.Fatal("notifications/cancelled not received")
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// PromptReference returns the reference to a prompt completed by Complete
+func PromptReference(name string) mcp.PromptReference {
+	return mcp.PromptReference{Type: "ref/prompt", Name: name}
+}
+
+// ResourceReference returns the reference to a resource template completed
+// by Complete
+func ResourceReference(uriTemplate string) mcp.ResourceReference {
+	return mcp.ResourceReference{Type: "ref/resource", URI: uriTemplate}
+}
+
+// complete sends completion/complete with the given client
+func (b *BaseAdapter) complete(ctx context.Context, client mcpclient.MCPClient, ref any, argument mcp.CompleteArgument, arguments map[string]string) (*mcp.Completion, error) {
+	if !b.connected {
+		return nil, fmt.Errorf("not connected to server")
+	}
+
+	request := mcp.CompleteRequest{}
+	request.Params.Ref = ref
+	request.Params.Argument = argument
+	request.Params.Context.Arguments = arguments
+
+	result, err := client.Complete(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete %s: %w", argument.Name, err)
+	}
+	return &result.Completion, nil
+}
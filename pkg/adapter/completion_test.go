@@ -0,0 +1,78 @@
+package adapter
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// completionProvider completes the language argument of prompts, and the
+// variables of resource templates from the other arguments
+type completionProvider struct {
+	prompt   string
+	uri      string
+	argument mcp.CompleteArgument
+	context  mcp.CompleteContext
+}
+
+func (p *completionProvider) CompletePromptArgument(ctx context.Context, promptName string, argument mcp.CompleteArgument, context mcp.CompleteContext) (*mcp.Completion, error) {
+	p.prompt, p.argument, p.context = promptName, argument, context
+	var values []string
+	for _, language := range []string{"go", "python", "rust"} {
+		if strings.HasPrefix(language, argument.Value) {
+			values = append(values, language)
+		}
+	}
+	return &mcp.Completion{Values: values}, nil
+}
+
+func (p *completionProvider) CompleteResourceArgument(ctx context.Context, uri string, argument mcp.CompleteArgument, context mcp.CompleteContext) (*mcp.Completion, error) {
+	p.uri, p.argument, p.context = uri, argument, context
+	return &mcp.Completion{Values: []string{context.Arguments["owner"] + "/" + argument.Value}, Total: 1}, nil
+}
+
+func TestComplete(t *testing.T) {
+	provider := &completionProvider{}
+	mcpServer := server.NewMCPServer("completion-test", "1.0.0",
+		server.WithCompletions(),
+		server.WithPromptCompletionProvider(provider),
+		server.WithResourceCompletionProvider(provider),
+	)
+	testServer := server.NewTestStreamableHTTPServer(mcpServer)
+	defer testServer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	adapter, err := NewAdapter(AdapterTypeAuto, Config{ServerURL: testServer.URL + "/mcp", Timeout: 5 * time.Second})
+	require.NoError(t, err)
+
+	_, err = adapter.Complete(ctx, PromptReference("review"), mcp.CompleteArgument{Name: "language"}, nil)
+	assert.EqualError(t, err, "not connected to server")
+
+	require.NoError(t, adapter.Connect(ctx))
+	defer func() { _ = adapter.Disconnect() }()
+
+	t.Run("prompt", func(t *testing.T) {
+		completion, err := adapter.Complete(ctx, PromptReference("review"), mcp.CompleteArgument{Name: "language", Value: "p"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"python"}, completion.Values)
+		assert.Equal(t, "review", provider.prompt)
+		assert.Equal(t, mcp.CompleteArgument{Name: "language", Value: "p"}, provider.argument)
+	})
+
+	t.Run("resource template", func(t *testing.T) {
+		completion, err := adapter.Complete(ctx, ResourceReference("repo://{owner}/{repo}"), mcp.CompleteArgument{Name: "repo", Value: "mcp"}, map[string]string{"owner": "jbovet"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"jbovet/mcp"}, completion.Values)
+		assert.Equal(t, 1, completion.Total)
+		assert.Equal(t, "repo://{owner}/{repo}", provider.uri)
+		assert.Equal(t, map[string]string{"owner": "jbovet"}, provider.context.Arguments)
+	})
+}
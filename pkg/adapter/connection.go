@@ -0,0 +1,134 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// pingInterval is the time between the pings checking a monitored connection,
+// and the time a ping may take
+var pingInterval = 10 * time.Second
+
+// ConnectionMonitor is implemented by adapters telling when the connection
+// to the server is lost
+type ConnectionMonitor interface {
+	// Done returns a channel closed when the connection is lost: the server
+	// process exited, the event stream closed, or the server stopped
+	// answering the pings sent once Done is called
+	Done() <-chan struct{}
+
+	// Err returns why the connection was lost, or nil while it is not
+	Err() error
+}
+
+// connectionState tracks the loss of the connection of an adapter
+type connectionState struct {
+	mu   sync.Mutex
+	lost chan struct{}
+	err  error
+	// stop ends the pings of a monitored connection
+	stop chan struct{}
+	// ping checks that the server answers, set once the client is started
+	ping func(ctx context.Context) error
+	// closed is set once the connection is closed by Disconnect, after
+	// which the end of the process or of the stream is expected
+	closed bool
+}
+
+// channel returns the channel closed when the connection is lost. The caller
+// must hold mu.
+func (c *connectionState) channel() chan struct{} {
+	if c.lost == nil {
+		c.lost = make(chan struct{})
+	}
+	return c.lost
+}
+
+// Done implements ConnectionMonitor. The server is pinged from the first
+// call until the connection is lost or closed.
+func (b *BaseAdapter) Done() <-chan struct{} {
+	c := &b.connection
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	lost := c.channel()
+	if c.stop == nil && c.err == nil && c.ping != nil {
+		c.stop = make(chan struct{})
+		go b.monitor(c.ping, pingInterval, c.stop)
+	}
+	return lost
+}
+
+// Err implements ConnectionMonitor
+func (b *BaseAdapter) Err() error {
+	b.connection.mu.Lock()
+	defer b.connection.mu.Unlock()
+	return b.connection.err
+}
+
+// connectionLost reports the loss of the connection, keeping the first cause
+func (b *BaseAdapter) connectionLost(err error) {
+	c := &b.connection
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil || c.closed {
+		return
+	}
+	b.logf("Connection to the server lost: %v", err)
+	c.err = err
+	close(c.channel())
+}
+
+// monitorPings sets the function checking the connection of a started
+// client
+func (b *BaseAdapter) monitorPings(ping func(ctx context.Context) error) {
+	b.connection.mu.Lock()
+	defer b.connection.mu.Unlock()
+	b.connection.ping = ping
+	b.connection.closed = false
+}
+
+// stopMonitor ends the pings when the connection is closed
+func (b *BaseAdapter) stopMonitor() {
+	c := &b.connection
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stop != nil {
+		close(c.stop)
+		c.stop = nil
+	}
+	c.ping = nil
+	c.closed = true
+}
+
+// monitor pings the server every interval until it fails to answer or stop
+// is closed
+func (b *BaseAdapter) monitor(ping func(ctx context.Context) error, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		err := ping(ctx)
+		cancel()
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				err = fmt.Errorf("no answer within %v", interval)
+			}
+			b.connectionLost(fmt.Errorf("server stopped answering pings: %w", err))
+			return
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package adapter
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionMonitor(t *testing.T) {
+	defer func(interval time.Duration) { pingInterval = interval }(pingInterval)
+	pingInterval = 50 * time.Millisecond
+
+	tests := []struct {
+		name        string
+		adapterType AdapterType
+		testServer  func(*server.MCPServer) *httptest.Server
+		path        string
+	}{
+		{
+			name:        "SSE",
+			adapterType: AdapterTypeSSE,
+			testServer:  func(s *server.MCPServer) *httptest.Server { return server.NewTestServer(s) },
+			path:        "/sse",
+		},
+		{
+			name:        "StreamableHTTP",
+			adapterType: AdapterTypeStreamable,
+			testServer:  func(s *server.MCPServer) *httptest.Server { return server.NewTestStreamableHTTPServer(s) },
+			path:        "/mcp",
+		},
+		{
+			name:        "Auto",
+			adapterType: AdapterTypeAuto,
+			testServer:  func(s *server.MCPServer) *httptest.Server { return server.NewTestStreamableHTTPServer(s) },
+			path:        "/mcp",
+		},
+	}
+
+	connect := func(t *testing.T, adapterType AdapterType, url string) ServerAdapter {
+		adapter, err := NewAdapter(adapterType, Config{ServerURL: url, Timeout: 5 * time.Second})
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		require.NoError(t, adapter.Connect(ctx))
+		return adapter
+	}
+
+	stopServer := func(testServer *httptest.Server) {
+		testServer.CloseClientConnections()
+		testServer.Close()
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Run("server stopped", func(t *testing.T) {
+				testServer := test.testServer(server.NewMCPServer("connection-test", "1.0.0"))
+				adapter := connect(t, test.adapterType, testServer.URL+test.path)
+				defer func() { _ = adapter.Disconnect() }()
+
+				done := adapter.Done()
+				select {
+				case <-done:
+					t.Fatal("connection reported lost while the server runs")
+				case <-time.After(3 * pingInterval):
+				}
+				assert.NoError(t, adapter.Err())
+
+				stopServer(testServer)
+				select {
+				case <-done:
+				case <-time.After(5 * time.Second):
+					t.Fatal("connection loss not reported")
+				}
+				assert.Error(t, adapter.Err())
+			})
+
+			t.Run("disconnected", func(t *testing.T) {
+				testServer := test.testServer(server.NewMCPServer("connection-test", "1.0.0"))
+				adapter := connect(t, test.adapterType, testServer.URL+test.path)
+
+				done := adapter.Done()
+				require.NoError(t, adapter.Disconnect())
+				stopServer(testServer)
+				select {
+				case <-done:
+					t.Fatalf("disconnection reported as a lost connection: %v", adapter.Err())
+				case <-time.After(5 * pingInterval):
+				}
+				assert.NoError(t, adapter.Err())
+			})
+		})
+	}
+}
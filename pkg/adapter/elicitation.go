@@ -0,0 +1,27 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ElicitationHandler answers the elicitation/create requests through which a
+// server asks the user for structured input. Returning an error rejects the
+// request.
+type ElicitationHandler interface {
+	Elicit(ctx context.Context, request mcp.ElicitationRequest) (*mcp.ElicitationResult, error)
+}
+
+// Elicitor is implemented by adapters answering server elicitation requests
+type Elicitor interface {
+	// SetElicitationHandler sets the handler of elicitation requests. It
+	// must be set before connecting, as the elicitation capability is only
+	// advertised to the server when a handler is set.
+	SetElicitationHandler(handler ElicitationHandler)
+}
+
+// SetElicitationHandler sets the handler of elicitation requests
+func (b *BaseAdapter) SetElicitationHandler(handler ElicitationHandler) {
+	b.elicitation = handler
+}
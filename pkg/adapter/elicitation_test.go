@@ -0,0 +1,94 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// elicitationFunc adapts a function to ElicitationHandler
+type elicitationFunc func(ctx context.Context, request mcp.ElicitationRequest) (*mcp.ElicitationResult, error)
+
+func (f elicitationFunc) Elicit(ctx context.Context, request mcp.ElicitationRequest) (*mcp.ElicitationResult, error) {
+	return f(ctx, request)
+}
+
+// newElicitationServer creates a server whose "greet" tool asks the user for
+// their name
+func newElicitationServer() *server.MCPServer {
+	mcpServer := server.NewMCPServer("elicitation-test", "1.0.0", server.WithElicitation())
+	mcpServer.AddTool(mcp.NewTool("greet"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		elicitationRequest := mcp.ElicitationRequest{}
+		elicitationRequest.Params.Message = "What is your name?"
+		elicitationRequest.Params.RequestedSchema = map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"name": map[string]any{"type": "string"}},
+			"required":   []string{"name"},
+		}
+
+		result, err := mcpServer.RequestElicitation(ctx, elicitationRequest)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if result.Action != mcp.ElicitationResponseActionAccept {
+			return mcp.NewToolResultText(string(result.Action)), nil
+		}
+		content, _ := result.Content.(map[string]any)
+		return mcp.NewToolResultText(fmt.Sprintf("Hello %v", content["name"])), nil
+	})
+	return mcpServer
+}
+
+func TestElicitation(t *testing.T) {
+	testServer := server.NewTestStreamableHTTPServer(newElicitationServer())
+	defer testServer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	greet := func(t *testing.T, handler ElicitationHandler) *mcp.CallToolResult {
+		adapter, err := NewAdapter(AdapterTypeAuto, Config{ServerURL: testServer.URL + "/mcp", Timeout: 5 * time.Second})
+		require.NoError(t, err)
+		if handler != nil {
+			adapter.SetElicitationHandler(handler)
+		}
+		require.NoError(t, adapter.Connect(ctx))
+		defer func() { _ = adapter.Disconnect() }()
+
+		result, err := adapter.CallTool(ctx, "greet", nil)
+		require.NoError(t, err)
+		require.Len(t, result.Content, 1)
+		return result
+	}
+
+	t.Run("accept", func(t *testing.T) {
+		var received mcp.ElicitationRequest
+		result := greet(t, elicitationFunc(func(ctx context.Context, request mcp.ElicitationRequest) (*mcp.ElicitationResult, error) {
+			received = request
+			return &mcp.ElicitationResult{ElicitationResponse: mcp.ElicitationResponse{
+				Action:  mcp.ElicitationResponseActionAccept,
+				Content: map[string]any{"name": "Ada"},
+			}}, nil
+		}))
+		assert.Equal(t, mcp.NewTextContent("Hello Ada"), result.Content[0])
+		assert.Equal(t, "What is your name?", received.Params.Message)
+	})
+
+	t.Run("decline", func(t *testing.T) {
+		result := greet(t, elicitationFunc(func(ctx context.Context, request mcp.ElicitationRequest) (*mcp.ElicitationResult, error) {
+			return &mcp.ElicitationResult{ElicitationResponse: mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionDecline}}, nil
+		}))
+		assert.Equal(t, mcp.NewTextContent("decline"), result.Content[0])
+	})
+
+	t.Run("without handler", func(t *testing.T) {
+		result := greet(t, nil)
+		assert.True(t, result.IsError)
+	})
+}
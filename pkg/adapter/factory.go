@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/jbovet/mcp-cli/pkg/oauth"
 )
 
 // AdapterFactory provides methods to create server adapters
@@ -22,8 +24,13 @@ func (f *AdapterFactory) CreateFromConfig(config map[string]interface{}) (Server
 	}
 
 	adapterConfig := Config{
+		Headers: getStringMap(config, "headers"),
+		OAuth:   getOAuthOptions(config, "oauth"),
 		Verbose: getBool(config, "verbose", false),
+		Secrets: getStringSlice(config, "secrets"),
 		Timeout: getDuration(config, "timeout", 30*time.Second),
+
+		Pagination: getPagination(config, "pagination"),
 	}
 
 	switch AdapterType(adapterType) {
@@ -41,6 +48,11 @@ func (f *AdapterFactory) CreateFromConfig(config map[string]interface{}) (Server
 		if env, ok := config["env"].([]string); ok {
 			adapterConfig.Env = env
 		}
+		adapterConfig.ExpandEnv = getBool(config, "expand_env", false)
+
+		if stderrLog, ok := config["stderr_log"].(string); ok {
+			adapterConfig.StderrLog = stderrLog
+		}
 
 		return NewStdioAdapter(adapterConfig)
 
@@ -53,12 +65,32 @@ func (f *AdapterFactory) CreateFromConfig(config map[string]interface{}) (Server
 
 		return NewHTTPAdapter(adapterConfig)
 
+	case AdapterTypeSSE:
+		url, ok := config["url"].(string)
+		if !ok {
+			return nil, fmt.Errorf("URL is required for SSE adapter")
+		}
+		adapterConfig.ServerURL = url
+
+		return NewSSEAdapter(adapterConfig)
+
+	case AdapterTypeAuto:
+		url, ok := config["url"].(string)
+		if !ok {
+			return nil, fmt.Errorf("URL is required for auto adapter")
+		}
+		adapterConfig.ServerURL = url
+
+		return NewAutoAdapter(adapterConfig)
+
 	default:
 		return nil, fmt.Errorf("unsupported adapter type: %s", adapterType)
 	}
 }
 
-// CreateFromURL creates an adapter from a URL string
+// CreateFromURL creates an adapter from a URL string. HTTP URLs get an
+// adapter that detects whether the server uses the streamable HTTP or the
+// legacy SSE transport when connecting.
 func (f *AdapterFactory) CreateFromURL(url string, verbose bool) (ServerAdapter, error) {
 	if strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
 		config := Config{
@@ -66,7 +98,7 @@ func (f *AdapterFactory) CreateFromURL(url string, verbose bool) (ServerAdapter,
 			Verbose:   verbose,
 			Timeout:   30 * time.Second,
 		}
-		return NewHTTPAdapter(config)
+		return NewAutoAdapter(config)
 	}
 
 	// Assume it's a command for stdio
@@ -92,7 +124,7 @@ func (f *AdapterFactory) ValidateConfig(adapterType AdapterType, config Config)
 		if config.Command == "" {
 			return fmt.Errorf("command is required for stdio adapter")
 		}
-	case AdapterTypeHTTP, AdapterTypeStreamable:
+	case AdapterTypeHTTP, AdapterTypeStreamable, AdapterTypeSSE, AdapterTypeAuto:
 		if config.ServerURL == "" {
 			return fmt.Errorf("server URL is required for HTTP adapter")
 		}
@@ -117,6 +149,8 @@ func (f *AdapterFactory) GetSupportedTypes() []AdapterType {
 		AdapterTypeStdio,
 		AdapterTypeHTTP,
 		AdapterTypeStreamable,
+		AdapterTypeSSE,
+		AdapterTypeAuto,
 	}
 }
 
@@ -127,6 +161,37 @@ func getBool(config map[string]interface{}, key string, defaultValue bool) bool
 	}
 	return defaultValue
 }
+func getStringMap(config map[string]interface{}, key string) map[string]string {
+	switch val := config[key].(type) {
+	case map[string]string:
+		return val
+	case map[string]interface{}:
+		result := make(map[string]string, len(val))
+		for k, v := range val {
+			result[k] = fmt.Sprint(v)
+		}
+		return result
+	}
+	return nil
+}
+func getStringSlice(config map[string]interface{}, key string) []string {
+	if val, ok := config[key].([]string); ok {
+		return val
+	}
+	return nil
+}
+func getOAuthOptions(config map[string]interface{}, key string) *oauth.Options {
+	if val, ok := config[key].(*oauth.Options); ok {
+		return val
+	}
+	return nil
+}
+func getPagination(config map[string]interface{}, key string) Pagination {
+	if val, ok := config[key].(Pagination); ok {
+		return val
+	}
+	return Pagination{}
+}
 func getDuration(config map[string]interface{}, key string, defaultValue time.Duration) time.Duration {
 	if val, ok := config[key].(time.Duration); ok {
 		return val
@@ -2,11 +2,15 @@ package adapter
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"iter"
 	"os"
 	"time"
 
+	"github.com/jbovet/mcp-cli/pkg/oauth"
 	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -41,17 +45,130 @@ func (h *HTTPAdapter) Connect(ctx context.Context) error {
 
 	h.logf("Connecting to MCP server via HTTP: %s", h.config.ServerURL)
 
-	// Create streamable HTTP client
-	client, err := mcpclient.NewStreamableHttpClient(h.config.ServerURL)
+	headers, err := h.config.httpHeaders()
 	if err != nil {
-		return fmt.Errorf("failed to create HTTP client: %w", err)
+		return err
 	}
 
-	h.client = client
-
 	ctx, cancel := context.WithTimeout(ctx, h.config.Timeout)
 	defer cancel()
 
+	var store *oauth.TokenStore
+	if h.config.OAuth != nil {
+		if store, err = oauth.NewTokenStore(h.config.OAuth.CacheDir, h.config.ServerURL); err != nil {
+			return err
+		}
+	}
+
+	var result *mcp.InitializeResult
+	if store != nil && store.HasToken() {
+		h.logf("Using cached OAuth token from %s", store.Path())
+		result, err = h.connectOAuth(ctx, headers, store)
+	} else {
+		result, err = h.connectPlain(ctx, headers)
+		if err != nil && store != nil && isUnauthorized(err) {
+			h.logf("Server requires authorization, starting OAuth flow")
+			result, err = h.connectOAuth(ctx, headers, store)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	h.setConnected(true)
+	h.setServerInfo(&result.ServerInfo)
+	h.logf("Successfully connected to server: %s %s", result.ServerInfo.Name, result.ServerInfo.Version)
+
+	return nil
+}
+
+// connectPlain initializes a session without OAuth
+func (h *HTTPAdapter) connectPlain(ctx context.Context, headers map[string]string) (*mcp.InitializeResult, error) {
+	// Create streamable HTTP client
+	trans, err := transport.NewStreamableHTTP(h.config.ServerURL, h.transportOptions(headers)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	return h.initialize(ctx, h.newClient(trans), nil)
+}
+
+// transportOptions configures the streamable HTTP transport. The client
+// listens for the messages the server sends outside of a request, which is
+// how servers send notifications between requests and their own requests,
+// such as sampling.
+func (h *HTTPAdapter) transportOptions(headers map[string]string) []transport.StreamableHTTPCOption {
+	return []transport.StreamableHTTPCOption{
+		transport.WithHTTPHeaders(headers),
+		transport.WithContinuousListening(),
+		transport.WithHTTPLogger(transportLogger{&h.BaseAdapter}),
+	}
+}
+
+// connectOAuth initializes a session authorized with an OAuth token. When no
+// valid token is cached and it cannot be refreshed, the authorization flow is
+// run and the initialization retried.
+func (h *HTTPAdapter) connectOAuth(ctx context.Context, headers map[string]string, store *oauth.TokenStore) (*mcp.InitializeResult, error) {
+	registration, err := store.Registration()
+	if err != nil {
+		return nil, err
+	}
+
+	var previousRedirectURI string
+	if registration != nil {
+		previousRedirectURI = registration.RedirectURI
+	}
+	listener, err := oauth.Listen(previousRedirectURI)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := listener.Close(); err != nil {
+			h.logf("Warning: failed to close redirect listener: %v", err)
+		}
+	}()
+
+	oauthConfig := transport.OAuthConfig{
+		ClientID:     h.config.OAuth.ClientID,
+		ClientSecret: h.config.OAuth.ClientSecret,
+		RedirectURI:  listener.RedirectURI(),
+		Scopes:       h.config.OAuth.Scopes,
+		TokenStore:   store,
+		PKCEEnabled:  true,
+	}
+	// A dynamically registered client is only reused when its redirect URI
+	// is still served, otherwise a new client is registered
+	if oauthConfig.ClientID == "" && registration != nil && registration.RedirectURI == listener.RedirectURI() {
+		oauthConfig.ClientID = registration.ClientID
+		oauthConfig.ClientSecret = registration.ClientSecret
+	}
+
+	trans, err := transport.NewStreamableHTTP(h.config.ServerURL, append(h.transportOptions(headers), transport.WithHTTPOAuth(oauthConfig))...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	return h.initialize(ctx, h.newClient(trans), func(err error) error {
+		handler := mcpclient.GetOAuthHandler(err)
+		if handler == nil {
+			return err
+		}
+		return oauth.Authorize(ctx, handler, store, listener, *h.config.OAuth)
+	})
+}
+
+// initialize sends the initialize request. When authorize is set, it is
+// called for an initialization error requiring OAuth authorization and the
+// request is retried once after it succeeded.
+func (h *HTTPAdapter) initialize(ctx context.Context, client *mcpclient.Client, authorize func(error) error) (*mcp.InitializeResult, error) {
+	// Starting the client installs its handler of server requests. The
+	// transport context must outlive ctx, like the session.
+	if err := client.Start(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to start HTTP client: %w", err)
+	}
+	h.listen(client)
+	h.client = client
+
 	// Initialize the connection
 	initRequest := mcp.InitializeRequest{}
 	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
@@ -61,19 +178,25 @@ func (h *HTTPAdapter) Connect(ctx context.Context) error {
 	}
 
 	result, err := h.client.Initialize(ctx, initRequest)
+	if err != nil && authorize != nil && mcpclient.IsOAuthAuthorizationRequiredError(err) {
+		if err = authorize(err); err == nil {
+			result, err = h.client.Initialize(ctx, initRequest)
+		}
+	}
 	if err != nil {
 		if err := h.client.Close(); err != nil {
 			// Log the error but don't return it since this is likely in a cleanup context
 			fmt.Fprintf(os.Stderr, "Warning: failed to close HTTP client: %v\n", err)
 		}
-		return fmt.Errorf("failed to initialize: %w", err)
+		return nil, fmt.Errorf("failed to initialize: %w", err)
 	}
 
-	h.setConnected(true)
-	h.setServerInfo(&result.ServerInfo)
-	h.logf("Successfully connected to server: %s %s", result.ServerInfo.Name, result.ServerInfo.Version)
+	return result, nil
+}
 
-	return nil
+// isUnauthorized reports whether a request was rejected with 401
+func isUnauthorized(err error) bool {
+	return errors.Is(err, transport.ErrUnauthorized)
 }
 
 // Disconnect closes the HTTP connection
@@ -83,58 +206,54 @@ func (h *HTTPAdapter) Disconnect() error {
 	}
 
 	h.logf("Disconnecting from MCP server")
+	h.stopMonitor()
 	err := h.client.Close()
 	h.setConnected(false)
 	h.setServerInfo(nil)
 	return err
 }
 
-// ListTools returns available tools from the server
+// ListTools returns available tools from the server, following cursors
+// up to the pagination limits
 func (h *HTTPAdapter) ListTools(ctx context.Context) ([]mcp.Tool, error) {
-	if !h.connected {
-		return nil, fmt.Errorf("not connected to server")
-	}
-
-	request := mcp.ListToolsRequest{}
-	result, err := h.client.ListTools(ctx, request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list tools: %w", err)
-	}
+	return collect(&h.BaseAdapter, "tools", h.ToolPages(ctx))
+}
 
-	return result.Tools, nil
+// ToolPages iterates over the pages of the tool listing
+func (h *HTTPAdapter) ToolPages(ctx context.Context) iter.Seq2[[]mcp.Tool, error] {
+	return h.toolPages(ctx, h.client)
 }
 
-// CallTool executes a tool on the server
+// CallTool executes a tool on the server, cancelling it when ctx is done
 func (h *HTTPAdapter) CallTool(ctx context.Context, name string, arguments map[string]any) (*mcp.CallToolResult, error) {
-	if !h.connected {
-		return nil, fmt.Errorf("not connected to server")
-	}
-
-	request := mcp.CallToolRequest{}
-	request.Params.Name = name
-	request.Params.Arguments = arguments
-
-	result, err := h.client.CallTool(ctx, request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to call tool %s: %w", name, err)
-	}
+	return h.callTool(ctx, h.client, name, arguments)
+}
 
-	return result, nil
+// SetLogLevel sets the minimum level of the log messages sent by the server
+func (h *HTTPAdapter) SetLogLevel(ctx context.Context, level mcp.LoggingLevel) error {
+	return h.setLogLevel(ctx, h.client, level)
 }
 
-// ListResources returns available resources from the server
+// ListResources returns available resources from the server, following cursors
+// up to the pagination limits
 func (h *HTTPAdapter) ListResources(ctx context.Context) ([]mcp.Resource, error) {
-	if !h.connected {
-		return nil, fmt.Errorf("not connected to server")
-	}
+	return collect(&h.BaseAdapter, "resources", h.ResourcePages(ctx))
+}
 
-	request := mcp.ListResourcesRequest{}
-	result, err := h.client.ListResources(ctx, request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list resources: %w", err)
-	}
+// ResourcePages iterates over the pages of the resource listing
+func (h *HTTPAdapter) ResourcePages(ctx context.Context) iter.Seq2[[]mcp.Resource, error] {
+	return h.resourcePages(ctx, h.client)
+}
+
+// ListResourceTemplates returns available resource templates from the
+// server, following cursors up to the pagination limits
+func (h *HTTPAdapter) ListResourceTemplates(ctx context.Context) ([]mcp.ResourceTemplate, error) {
+	return collect(&h.BaseAdapter, "resource templates", h.ResourceTemplatePages(ctx))
+}
 
-	return result.Resources, nil
+// ResourceTemplatePages iterates over the pages of the resource template listing
+func (h *HTTPAdapter) ResourceTemplatePages(ctx context.Context) iter.Seq2[[]mcp.ResourceTemplate, error] {
+	return h.resourceTemplatePages(ctx, h.client)
 }
 
 // ReadResource reads a specific resource
@@ -154,19 +273,25 @@ func (h *HTTPAdapter) ReadResource(ctx context.Context, uri string) (*mcp.ReadRe
 	return result, nil
 }
 
-// ListPrompts returns available prompts from the server
-func (h *HTTPAdapter) ListPrompts(ctx context.Context) ([]mcp.Prompt, error) {
-	if !h.connected {
-		return nil, fmt.Errorf("not connected to server")
-	}
+// Subscribe subscribes to the updates of a resource
+func (h *HTTPAdapter) Subscribe(ctx context.Context, uri string) error {
+	return h.subscribe(ctx, h.client, uri)
+}
 
-	request := mcp.ListPromptsRequest{}
-	result, err := h.client.ListPrompts(ctx, request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list prompts: %w", err)
-	}
+// Unsubscribe cancels the subscription to the updates of a resource
+func (h *HTTPAdapter) Unsubscribe(ctx context.Context, uri string) error {
+	return h.unsubscribe(ctx, h.client, uri)
+}
 
-	return result.Prompts, nil
+// ListPrompts returns available prompts from the server, following cursors
+// up to the pagination limits
+func (h *HTTPAdapter) ListPrompts(ctx context.Context) ([]mcp.Prompt, error) {
+	return collect(&h.BaseAdapter, "prompts", h.PromptPages(ctx))
+}
+
+// PromptPages iterates over the pages of the prompt listing
+func (h *HTTPAdapter) PromptPages(ctx context.Context) iter.Seq2[[]mcp.Prompt, error] {
+	return h.promptPages(ctx, h.client)
 }
 
 // GetPrompt retrieves a specific prompt
@@ -186,3 +311,9 @@ func (h *HTTPAdapter) GetPrompt(ctx context.Context, name string, arguments map[
 
 	return result, nil
 }
+
+// Complete asks the server for the values completing a prompt argument or a
+// resource template variable
+func (h *HTTPAdapter) Complete(ctx context.Context, ref any, argument mcp.CompleteArgument, arguments map[string]string) (*mcp.Completion, error) {
+	return h.complete(ctx, h.client, ref, argument, arguments)
+}
@@ -0,0 +1,122 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// NotificationHandler is called for each notification sent by the server,
+// such as notifications/message or notifications/tools/list_changed. It is
+// called from the goroutine reading the transport, so it must not block.
+type NotificationHandler func(notification mcp.JSONRPCNotification)
+
+// Notifier is implemented by adapters delivering server notifications
+type Notifier interface {
+	// OnNotification registers a handler called for every notification
+	// received from the server. Handlers can be registered before
+	// connecting and are kept across reconnections.
+	OnNotification(handler NotificationHandler)
+
+	// SetLogLevel asks the server to send log messages at or above level
+	// as notifications/message
+	SetLogLevel(ctx context.Context, level mcp.LoggingLevel) error
+}
+
+// LoggingLevels returns the log levels of the MCP specification, from the
+// most to the least verbose
+func LoggingLevels() []mcp.LoggingLevel {
+	return []mcp.LoggingLevel{
+		mcp.LoggingLevelDebug,
+		mcp.LoggingLevelInfo,
+		mcp.LoggingLevelNotice,
+		mcp.LoggingLevelWarning,
+		mcp.LoggingLevelError,
+		mcp.LoggingLevelCritical,
+		mcp.LoggingLevelAlert,
+		mcp.LoggingLevelEmergency,
+	}
+}
+
+// ParseLoggingLevel validates a log level name
+func ParseLoggingLevel(s string) (mcp.LoggingLevel, error) {
+	level := mcp.LoggingLevel(s)
+	if !slices.Contains(LoggingLevels(), level) {
+		return "", fmt.Errorf("invalid log level %q (must be one of debug, info, notice, warning, error, critical, alert, emergency)", s)
+	}
+	return level, nil
+}
+
+// OnNotification registers a handler for server notifications
+func (b *BaseAdapter) OnNotification(handler NotificationHandler) {
+	b.notifyMu.Lock()
+	defer b.notifyMu.Unlock()
+	b.handlers = append(b.handlers, handler)
+}
+
+// listen passes the notifications received by a started client to the
+// handlers, and monitors its connection with pings once Done is called
+func (b *BaseAdapter) listen(client *mcpclient.Client) {
+	client.OnNotification(b.notify)
+	b.monitorPings(client.Ping)
+}
+
+// notify passes a notification to the registered handlers
+func (b *BaseAdapter) notify(notification mcp.JSONRPCNotification) {
+	// The stdio transport passes an empty message when its pipe is closed
+	if notification.Method == "" {
+		return
+	}
+	b.logf("Received notification: %s", notification.Method)
+
+	b.notifyMu.RLock()
+	defer b.notifyMu.RUnlock()
+	for _, handler := range b.handlers {
+		handler(notification)
+	}
+}
+
+// setLogLevel sends logging/setLevel with the given client
+func (b *BaseAdapter) setLogLevel(ctx context.Context, client mcpclient.MCPClient, level mcp.LoggingLevel) error {
+	if !b.connected {
+		return fmt.Errorf("not connected to server")
+	}
+
+	request := mcp.SetLevelRequest{}
+	request.Params.Level = level
+	if err := client.SetLevel(ctx, request); err != nil {
+		return fmt.Errorf("failed to set log level %s: %w", level, err)
+	}
+	return nil
+}
+
+// subscribe sends resources/subscribe with the given client
+func (b *BaseAdapter) subscribe(ctx context.Context, client mcpclient.MCPClient, uri string) error {
+	if !b.connected {
+		return fmt.Errorf("not connected to server")
+	}
+
+	request := mcp.SubscribeRequest{}
+	request.Params.URI = uri
+	if err := client.Subscribe(ctx, request); err != nil {
+		return fmt.Errorf("failed to subscribe to resource %s: %w", uri, err)
+	}
+	return nil
+}
+
+// unsubscribe sends resources/unsubscribe with the given client
+func (b *BaseAdapter) unsubscribe(ctx context.Context, client mcpclient.MCPClient, uri string) error {
+	if !b.connected {
+		return fmt.Errorf("not connected to server")
+	}
+
+	request := mcp.UnsubscribeRequest{}
+	request.Params.URI = uri
+	if err := client.Unsubscribe(ctx, request); err != nil {
+		return fmt.Errorf("failed to unsubscribe from resource %s: %w", uri, err)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newNotifyingServer creates a server whose "notify" tool sends a log message
+// and a tool list change before answering
+func newNotifyingServer() *server.MCPServer {
+	mcpServer := server.NewMCPServer("notify-test", "1.0.0", server.WithLogging())
+	mcpServer.AddTool(mcp.NewTool("notify"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if err := mcpServer.SendNotificationToClient(ctx, "notifications/message", map[string]any{
+			"level":  "warning",
+			"logger": "test",
+			"data":   "disk almost full",
+		}); err != nil {
+			return nil, err
+		}
+		if err := mcpServer.SendNotificationToClient(ctx, mcp.MethodNotificationToolsListChanged, nil); err != nil {
+			return nil, err
+		}
+		// The streamable HTTP server drops notifications still queued when the
+		// response is written
+		time.Sleep(50 * time.Millisecond)
+		return mcp.NewToolResultText("done"), nil
+	})
+	return mcpServer
+}
+
+func TestNotifications(t *testing.T) {
+	tests := []struct {
+		name        string
+		adapterType AdapterType
+		testServer  func(*server.MCPServer) *httptest.Server
+		path        string
+	}{
+		{
+			name:        "SSE",
+			adapterType: AdapterTypeSSE,
+			testServer:  func(s *server.MCPServer) *httptest.Server { return server.NewTestServer(s) },
+			path:        "/sse",
+		},
+		{
+			name:        "StreamableHTTP",
+			adapterType: AdapterTypeStreamable,
+			testServer:  func(s *server.MCPServer) *httptest.Server { return server.NewTestStreamableHTTPServer(s) },
+			path:        "/mcp",
+		},
+		{
+			name:        "Auto",
+			adapterType: AdapterTypeAuto,
+			testServer:  func(s *server.MCPServer) *httptest.Server { return server.NewTestStreamableHTTPServer(s) },
+			path:        "/mcp",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testServer := test.testServer(newNotifyingServer())
+			defer testServer.Close()
+
+			adapter, err := NewAdapter(test.adapterType, Config{ServerURL: testServer.URL + test.path, Timeout: 5 * time.Second})
+			require.NoError(t, err)
+
+			received := make(chan mcp.JSONRPCNotification, 10)
+			adapter.OnNotification(func(notification mcp.JSONRPCNotification) {
+				received <- notification
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			require.NoError(t, adapter.Connect(ctx))
+			defer func() { _ = adapter.Disconnect() }()
+
+			_, err = adapter.CallTool(ctx, "notify", nil)
+			require.NoError(t, err)
+
+			var methods []string
+			for len(methods) < 2 {
+				select {
+				case notification := <-received:
+					methods = append(methods, notification.Method)
+					if notification.Method == "notifications/message" {
+						assert.Equal(t, "disk almost full", notification.Params.AdditionalFields["data"])
+					}
+				case <-ctx.Done():
+					t.Fatalf("notifications not received, got %v", methods)
+				}
+			}
+			assert.ElementsMatch(t, []string{"notifications/message", mcp.MethodNotificationToolsListChanged}, methods)
+		})
+	}
+}
+
+func TestSetLogLevel(t *testing.T) {
+	testServer := server.NewTestServer(newNotifyingServer())
+	defer testServer.Close()
+
+	adapter, err := NewSSEAdapter(Config{ServerURL: testServer.URL + "/sse", Timeout: 5 * time.Second})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	assert.EqualError(t, adapter.SetLogLevel(ctx, mcp.LoggingLevelDebug), "not connected to server")
+
+	require.NoError(t, adapter.Connect(ctx))
+	defer func() { _ = adapter.Disconnect() }()
+	assert.NoError(t, adapter.SetLogLevel(ctx, mcp.LoggingLevelDebug))
+}
+
+func TestParseLoggingLevel(t *testing.T) {
+	level, err := ParseLoggingLevel("warning")
+	require.NoError(t, err)
+	assert.Equal(t, mcp.LoggingLevelWarning, level)
+
+	_, err = ParseLoggingLevel("verbose")
+	assert.ErrorContains(t, err, `invalid log level "verbose"`)
+}
+
+// subscriptionHandler answers resources/subscribe and resources/unsubscribe,
+// which the mcp-go server does not implement, and passes other requests on
+func subscriptionHandler(next http.Handler, requests chan<- string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var message struct {
+			ID     any    `json:"id"`
+			Method string `json:"method"`
+			Params struct {
+				URI string `json:"uri"`
+			} `json:"params"`
+		}
+		if err := json.Unmarshal(body, &message); err == nil && strings.HasPrefix(message.Method, "resources/") && strings.HasSuffix(message.Method, "subscribe") {
+			requests <- message.Method + " " + message.Params.URI
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": message.ID, "result": map[string]any{}})
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	t.Run("subscribe and unsubscribe", func(t *testing.T) {
+		requests := make(chan string, 2)
+		testServer := httptest.NewServer(subscriptionHandler(server.NewStreamableHTTPServer(newNotifyingServer()), requests))
+		defer testServer.Close()
+
+		adapter, err := NewHTTPAdapter(Config{ServerURL: testServer.URL, Timeout: 5 * time.Second})
+		require.NoError(t, err)
+		assert.EqualError(t, adapter.Subscribe(ctx, "file:///status"), "not connected to server")
+
+		require.NoError(t, adapter.Connect(ctx))
+		defer func() { _ = adapter.Disconnect() }()
+
+		require.NoError(t, adapter.Subscribe(ctx, "file:///status"))
+		require.NoError(t, adapter.Unsubscribe(ctx, "file:///status"))
+		assert.Equal(t, "resources/subscribe file:///status", <-requests)
+		assert.Equal(t, "resources/unsubscribe file:///status", <-requests)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		testServer := server.NewTestStreamableHTTPServer(newNotifyingServer())
+		defer testServer.Close()
+
+		adapter, err := NewHTTPAdapter(Config{ServerURL: testServer.URL + "/mcp", Timeout: 5 * time.Second})
+		require.NoError(t, err)
+		require.NoError(t, adapter.Connect(ctx))
+		defer func() { _ = adapter.Disconnect() }()
+
+		assert.ErrorContains(t, adapter.Subscribe(ctx, "file:///status"), "failed to subscribe to resource file:///status")
+	})
+}
@@ -0,0 +1,214 @@
+package adapter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jbovet/mcp-cli/pkg/oauth"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// authServer is a stand-in for an MCP server protected by an OAuth 2.1
+// authorization server supporting discovery, dynamic client registration
+// and PKCE
+type authServer struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	clientID      string
+	challenge     string
+	accessToken   string
+	refreshToken  string
+	registrations int
+	refreshes     int
+	tokensIssued  int
+}
+
+func newAuthServer(t *testing.T) *authServer {
+	s := &authServer{}
+	mcpHandler := server.NewStreamableHTTPServer(server.NewMCPServer("oauth-test", "1.0.0"))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/oauth-protected-resource", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"resource":              s.URL + "/mcp",
+			"authorization_servers": []string{s.URL},
+		})
+	})
+	mux.HandleFunc("/.well-known/oauth-authorization-server", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"issuer":                           s.URL,
+			"authorization_endpoint":           s.URL + "/authorize",
+			"token_endpoint":                   s.URL + "/token",
+			"registration_endpoint":            s.URL + "/register",
+			"response_types_supported":         []string{"code"},
+			"code_challenge_methods_supported": []string{"S256"},
+		})
+	})
+	mux.HandleFunc("/register", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.registrations++
+		s.clientID = "client-" + string(rune('0'+s.registrations))
+		writeJSON(w, http.StatusCreated, map[string]any{"client_id": s.clientID})
+	})
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		s.mu.Lock()
+		valid := query.Get("client_id") == s.clientID && query.Get("code_challenge_method") == "S256"
+		s.challenge = query.Get("code_challenge")
+		s.mu.Unlock()
+
+		redirect, err := url.Parse(query.Get("redirect_uri"))
+		require.NoError(t, err)
+		params := url.Values{"state": {query.Get("state")}}
+		if valid {
+			params.Set("code", "code-1")
+		} else {
+			params.Set("error", "invalid_request")
+		}
+		redirect.RawQuery = params.Encode()
+		http.Redirect(w, r, redirect.String(), http.StatusFound)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		switch r.Form.Get("grant_type") {
+		case "authorization_code":
+			sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+			if r.Form.Get("code") != "code-1" || base64.RawURLEncoding.EncodeToString(sum[:]) != s.challenge {
+				writeJSON(w, http.StatusBadRequest, map[string]any{"error": "invalid_grant"})
+				return
+			}
+		case "refresh_token":
+			if r.Form.Get("refresh_token") != s.refreshToken {
+				writeJSON(w, http.StatusBadRequest, map[string]any{"error": "invalid_grant"})
+				return
+			}
+			s.refreshes++
+		default:
+			writeJSON(w, http.StatusBadRequest, map[string]any{"error": "unsupported_grant_type"})
+			return
+		}
+
+		s.tokensIssued++
+		s.accessToken = "access-" + string(rune('0'+s.tokensIssued))
+		s.refreshToken = "refresh-" + string(rune('0'+s.tokensIssued))
+		writeJSON(w, http.StatusOK, map[string]any{
+			"access_token":  s.accessToken,
+			"token_type":    "Bearer",
+			"refresh_token": s.refreshToken,
+			"expires_in":    3600,
+		})
+	})
+	mux.HandleFunc("/mcp", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		authorized := s.accessToken != "" && r.Header.Get("Authorization") == "Bearer "+s.accessToken
+		s.mu.Unlock()
+		if !authorized {
+			w.Header().Set("WWW-Authenticate", `Bearer resource_metadata="`+s.URL+`/.well-known/oauth-protected-resource"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		mcpHandler.ServeHTTP(w, r)
+	})
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestHTTPAdapterOAuth(t *testing.T) {
+	authServer := newAuthServer(t)
+	cacheDir := t.TempDir()
+
+	var opened int
+	options := &oauth.Options{
+		CacheDir: cacheDir,
+		OpenURL: func(authURL string) error {
+			opened++
+			// Stand-in for the user approving the request in a browser
+			resp, err := http.Get(authURL)
+			if err != nil {
+				return err
+			}
+			return resp.Body.Close()
+		},
+	}
+
+	connect := func(t *testing.T) {
+		adapter, err := NewHTTPAdapter(Config{ServerURL: authServer.URL + "/mcp", OAuth: options, Timeout: 10 * time.Second})
+		require.NoError(t, err)
+
+		require.NoError(t, adapter.Connect(context.Background()))
+		info, err := adapter.GetServerInfo()
+		require.NoError(t, err)
+		assert.Equal(t, "oauth-test", info.Name)
+		assert.NoError(t, adapter.Disconnect())
+	}
+
+	store, err := oauth.NewTokenStore(cacheDir, authServer.URL+"/mcp")
+	require.NoError(t, err)
+
+	t.Run("AuthorizationFlow", func(t *testing.T) {
+		connect(t)
+		assert.Equal(t, 1, opened)
+		assert.Equal(t, 1, authServer.registrations)
+
+		info, err := os.Stat(store.Path())
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+		token, err := store.GetToken(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "access-1", token.AccessToken)
+	})
+
+	t.Run("CachedToken", func(t *testing.T) {
+		connect(t)
+		assert.Equal(t, 1, opened)
+		assert.Equal(t, 1, authServer.tokensIssued)
+	})
+
+	t.Run("RefreshExpiredToken", func(t *testing.T) {
+		token, err := store.GetToken(context.Background())
+		require.NoError(t, err)
+		token.ExpiresAt = time.Now().Add(-time.Minute)
+		require.NoError(t, store.SaveToken(context.Background(), token))
+
+		connect(t)
+		assert.Equal(t, 1, opened)
+		assert.Equal(t, 1, authServer.refreshes)
+
+		token, err = store.GetToken(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "access-2", token.AccessToken)
+	})
+
+	t.Run("WithoutOAuth", func(t *testing.T) {
+		adapter, err := NewHTTPAdapter(Config{ServerURL: authServer.URL + "/mcp", Timeout: 5 * time.Second})
+		require.NoError(t, err)
+
+		err = adapter.Connect(context.Background())
+		assert.ErrorContains(t, err, "401")
+	})
+}
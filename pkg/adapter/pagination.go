@@ -0,0 +1,187 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Default limits of listings, guarding against servers paginating endlessly
+const (
+	DefaultMaxPages = 100
+	DefaultMaxItems = 10000
+)
+
+// Pagination limits how far listings follow cursors. The page size itself
+// is chosen by the server.
+type Pagination struct {
+	// MaxPages is the maximum number of pages requested by a listing,
+	// DefaultMaxPages when 0
+	MaxPages int
+
+	// MaxItems is the maximum number of items returned by a listing,
+	// DefaultMaxItems when 0
+	MaxItems int
+
+	// Truncated is called with a warning when a listing stops at a limit.
+	// The warning is only logged when nil.
+	Truncated func(warning string)
+}
+
+// truncated reports a listing stopped at a limit
+func (b *BaseAdapter) truncated(format string, args ...any) {
+	warning := fmt.Sprintf(format, args...)
+	b.logf("Warning: %s", warning)
+	if b.config.Pagination.Truncated != nil {
+		b.config.Pagination.Truncated(warning)
+	}
+}
+
+func (p Pagination) maxPages() int {
+	if p.MaxPages > 0 {
+		return p.MaxPages
+	}
+	return DefaultMaxPages
+}
+
+func (p Pagination) maxItems() int {
+	if p.MaxItems > 0 {
+		return p.MaxItems
+	}
+	return DefaultMaxItems
+}
+
+// Pager is implemented by adapters that can stream listings page by page
+type Pager interface {
+	// ToolPages iterates over the pages of the tool listing
+	ToolPages(ctx context.Context) iter.Seq2[[]mcp.Tool, error]
+
+	// ResourcePages iterates over the pages of the resource listing
+	ResourcePages(ctx context.Context) iter.Seq2[[]mcp.Resource, error]
+
+	// ResourceTemplatePages iterates over the pages of the resource template
+	// listing
+	ResourceTemplatePages(ctx context.Context) iter.Seq2[[]mcp.ResourceTemplate, error]
+
+	// PromptPages iterates over the pages of the prompt listing
+	PromptPages(ctx context.Context) iter.Seq2[[]mcp.Prompt, error]
+}
+
+// fetchPage requests the page of a listing starting at cursor and returns its
+// items and the cursor of the next page
+type fetchPage[T any] func(ctx context.Context, cursor mcp.Cursor) ([]T, mcp.Cursor, error)
+
+// pages iterates over the pages of a listing, following cursors until the
+// last page or the page limit. Iteration stops after the first error.
+func pages[T any](ctx context.Context, b *BaseAdapter, kind string, fetch fetchPage[T]) iter.Seq2[[]T, error] {
+	return func(yield func([]T, error) bool) {
+		if !b.connected {
+			yield(nil, fmt.Errorf("not connected to server"))
+			return
+		}
+
+		var cursor mcp.Cursor
+		seen := make(map[mcp.Cursor]bool)
+		for page := 1; ; page++ {
+			items, next, err := fetch(ctx, cursor)
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to list %s: %w", kind, err))
+				return
+			}
+			if !yield(items, nil) || next == "" {
+				return
+			}
+
+			if seen[next] {
+				yield(nil, fmt.Errorf("failed to list %s: server returned cursor %q twice", kind, next))
+				return
+			}
+			if page >= b.config.Pagination.maxPages() {
+				b.truncated("listing of %s stopped after %d pages", kind, page)
+				return
+			}
+			seen[next] = true
+			cursor = next
+			b.logf("Fetching page %d of %s", page+1, kind)
+		}
+	}
+}
+
+// collect gathers the items of all pages of a listing, up to the item limit.
+// When a page ends exactly at the limit, the next page is requested to know
+// whether the listing is complete.
+func collect[T any](b *BaseAdapter, kind string, pages iter.Seq2[[]T, error]) ([]T, error) {
+	maxItems := b.config.Pagination.maxItems()
+	all := []T{}
+	for items, err := range pages {
+		// Pages only follow while the server returns a cursor
+		if len(all) == maxItems {
+			b.truncated("listing of %s stopped after %d items", kind, maxItems)
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if len(all) > maxItems {
+			b.truncated("listing of %s stopped after %d items", kind, maxItems)
+			all = all[:maxItems]
+			break
+		}
+	}
+	return all, nil
+}
+
+// toolPages, resourcePages, resourceTemplatePages and promptPages list with
+// the given client
+
+func (b *BaseAdapter) toolPages(ctx context.Context, client mcpclient.MCPClient) iter.Seq2[[]mcp.Tool, error] {
+	return pages(ctx, b, "tools", func(ctx context.Context, cursor mcp.Cursor) ([]mcp.Tool, mcp.Cursor, error) {
+		request := mcp.ListToolsRequest{}
+		request.Params.Cursor = cursor
+		result, err := client.ListToolsByPage(ctx, request)
+		if err != nil {
+			return nil, "", err
+		}
+		return result.Tools, result.NextCursor, nil
+	})
+}
+
+func (b *BaseAdapter) resourcePages(ctx context.Context, client mcpclient.MCPClient) iter.Seq2[[]mcp.Resource, error] {
+	return pages(ctx, b, "resources", func(ctx context.Context, cursor mcp.Cursor) ([]mcp.Resource, mcp.Cursor, error) {
+		request := mcp.ListResourcesRequest{}
+		request.Params.Cursor = cursor
+		result, err := client.ListResourcesByPage(ctx, request)
+		if err != nil {
+			return nil, "", err
+		}
+		return result.Resources, result.NextCursor, nil
+	})
+}
+
+func (b *BaseAdapter) resourceTemplatePages(ctx context.Context, client mcpclient.MCPClient) iter.Seq2[[]mcp.ResourceTemplate, error] {
+	return pages(ctx, b, "resource templates", func(ctx context.Context, cursor mcp.Cursor) ([]mcp.ResourceTemplate, mcp.Cursor, error) {
+		request := mcp.ListResourceTemplatesRequest{}
+		request.Params.Cursor = cursor
+		result, err := client.ListResourceTemplatesByPage(ctx, request)
+		if err != nil {
+			return nil, "", err
+		}
+		return result.ResourceTemplates, result.NextCursor, nil
+	})
+}
+
+func (b *BaseAdapter) promptPages(ctx context.Context, client mcpclient.MCPClient) iter.Seq2[[]mcp.Prompt, error] {
+	return pages(ctx, b, "prompts", func(ctx context.Context, cursor mcp.Cursor) ([]mcp.Prompt, mcp.Cursor, error) {
+		request := mcp.ListPromptsRequest{}
+		request.Params.Cursor = cursor
+		result, err := client.ListPromptsByPage(ctx, request)
+		if err != nil {
+			return nil, "", err
+		}
+		return result.Prompts, result.NextCursor, nil
+	})
+}
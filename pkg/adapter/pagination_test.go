@@ -0,0 +1,153 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPagedServer starts a streamable HTTP server returning two items per page
+func newPagedServer(t *testing.T) string {
+	mcpServer := server.NewMCPServer("paged", "1.0.0", server.WithPaginationLimit(2))
+	for i := 1; i <= 5; i++ {
+		mcpServer.AddTool(mcp.NewTool(fmt.Sprintf("tool-%d", i)), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		})
+	}
+	for i := 1; i <= 3; i++ {
+		name := fmt.Sprintf("resource-%d", i)
+		mcpServer.AddResource(mcp.NewResource("file:///"+name, name), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return nil, nil
+		})
+		mcpServer.AddPrompt(mcp.NewPrompt(fmt.Sprintf("prompt-%d", i)), func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			return &mcp.GetPromptResult{}, nil
+		})
+		mcpServer.AddResourceTemplate(mcp.NewResourceTemplate(fmt.Sprintf("file:///template-%d/{name}", i), fmt.Sprintf("template-%d", i)), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return nil, nil
+		})
+	}
+
+	testServer := server.NewTestStreamableHTTPServer(mcpServer)
+	t.Cleanup(testServer.Close)
+	return testServer.URL + "/mcp"
+}
+
+func connectPaged(t *testing.T, pagination Pagination) *HTTPAdapter {
+	adapter, err := NewHTTPAdapter(Config{ServerURL: newPagedServer(t), Timeout: 5 * time.Second, Pagination: pagination})
+	require.NoError(t, err)
+	require.NoError(t, adapter.Connect(context.Background()))
+	t.Cleanup(func() { _ = adapter.Disconnect() })
+	return adapter
+}
+
+func TestMultiPageListing(t *testing.T) {
+	ctx := context.Background()
+	adapter := connectPaged(t, Pagination{})
+
+	t.Run("follows cursors", func(t *testing.T) {
+		tools, err := adapter.ListTools(ctx)
+		require.NoError(t, err)
+		require.Len(t, tools, 5)
+		assert.Equal(t, "tool-1", tools[0].Name)
+		assert.Equal(t, "tool-5", tools[4].Name)
+
+		resources, err := adapter.ListResources(ctx)
+		require.NoError(t, err)
+		assert.Len(t, resources, 3)
+
+		templates, err := adapter.ListResourceTemplates(ctx)
+		require.NoError(t, err)
+		require.Len(t, templates, 3)
+		assert.Equal(t, "file:///template-1/{name}", templates[0].URITemplate.Raw())
+
+		prompts, err := adapter.ListPrompts(ctx)
+		require.NoError(t, err)
+		assert.Len(t, prompts, 3)
+	})
+
+	t.Run("streams pages", func(t *testing.T) {
+		var sizes []int
+		for tools, err := range adapter.ToolPages(ctx) {
+			require.NoError(t, err)
+			sizes = append(sizes, len(tools))
+		}
+		assert.Equal(t, []int{2, 2, 1}, sizes)
+	})
+
+	t.Run("stops when the caller does", func(t *testing.T) {
+		pages := 0
+		for _, err := range adapter.PromptPages(ctx) {
+			require.NoError(t, err)
+			pages++
+			break
+		}
+		assert.Equal(t, 1, pages)
+	})
+}
+
+func TestPaginationLimits(t *testing.T) {
+	ctx := context.Background()
+
+	var warnings []string
+	truncated := func(warning string) {
+		warnings = append(warnings, warning)
+	}
+
+	t.Run("max pages", func(t *testing.T) {
+		warnings = nil
+		tools, err := connectPaged(t, Pagination{MaxPages: 2, Truncated: truncated}).ListTools(ctx)
+		require.NoError(t, err)
+		assert.Len(t, tools, 4)
+		assert.Equal(t, []string{"listing of tools stopped after 2 pages"}, warnings)
+	})
+
+	t.Run("max items", func(t *testing.T) {
+		warnings = nil
+		tools, err := connectPaged(t, Pagination{MaxItems: 3, Truncated: truncated}).ListTools(ctx)
+		require.NoError(t, err)
+		assert.Len(t, tools, 3)
+		assert.Equal(t, []string{"listing of tools stopped after 3 items"}, warnings)
+	})
+
+	t.Run("max items at the end of a page", func(t *testing.T) {
+		warnings = nil
+		tools, err := connectPaged(t, Pagination{MaxItems: 4, Truncated: truncated}).ListTools(ctx)
+		require.NoError(t, err)
+		assert.Len(t, tools, 4)
+		assert.Equal(t, []string{"listing of tools stopped after 4 items"}, warnings)
+	})
+
+	t.Run("max items at the end of the listing", func(t *testing.T) {
+		warnings = nil
+		tools, err := connectPaged(t, Pagination{MaxItems: 5, Truncated: truncated}).ListTools(ctx)
+		require.NoError(t, err)
+		assert.Len(t, tools, 5)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("repeated cursor", func(t *testing.T) {
+		b := &BaseAdapter{connected: true}
+		requests := 0
+		loop := pages(ctx, b, "tools", func(ctx context.Context, cursor mcp.Cursor) ([]mcp.Tool, mcp.Cursor, error) {
+			requests++
+			return []mcp.Tool{{Name: "t"}}, "same", nil
+		})
+
+		_, err := collect(b, "tools", loop)
+		assert.ErrorContains(t, err, `server returned cursor "same" twice`)
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("not connected", func(t *testing.T) {
+		adapter, err := NewHTTPAdapter(Config{ServerURL: "http://localhost:1/mcp"})
+		require.NoError(t, err)
+		_, err = adapter.ListTools(ctx)
+		assert.EqualError(t, err, "not connected to server")
+	})
+}
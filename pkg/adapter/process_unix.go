@@ -0,0 +1,14 @@
+//go:build !windows
+
+package adapter
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// detachProcess starts the server in its own process group, so that Ctrl+C
+// in the terminal interrupts the CLI but not the server
+func detachProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
@@ -0,0 +1,14 @@
+//go:build windows
+
+package adapter
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// detachProcess starts the server in its own process group, so that Ctrl+C
+// in the console interrupts the CLI but not the server
+func detachProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
@@ -0,0 +1,139 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Roots is the list of filesystem roots offered to servers. It answers the
+// roots/list requests of the server and can be changed while connected, in
+// which case the server is told with NotifyRootsChanged.
+type Roots struct {
+	mu    sync.RWMutex
+	roots []mcp.Root
+}
+
+// NewRoots creates a list of roots
+func NewRoots(roots ...mcp.Root) *Roots {
+	return &Roots{roots: roots}
+}
+
+// List returns a copy of the roots
+func (r *Roots) List() []mcp.Root {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Clone(r.roots)
+}
+
+// Add appends a root and reports whether it was not already listed
+func (r *Roots) Add(root mcp.Root) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, listed := range r.roots {
+		if listed.URI == root.URI {
+			return false
+		}
+	}
+	r.roots = append(r.roots, root)
+	return true
+}
+
+// Remove removes the root with the given URI and reports whether it was
+// listed
+func (r *Roots) Remove(uri string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, listed := range r.roots {
+		if listed.URI == uri {
+			r.roots = slices.Delete(r.roots, i, i+1)
+			return true
+		}
+	}
+	return false
+}
+
+// ListRoots answers the roots/list requests of the server
+func (r *Roots) ListRoots(ctx context.Context, request mcp.ListRootsRequest) (*mcp.ListRootsResult, error) {
+	return &mcp.ListRootsResult{Roots: r.List()}, nil
+}
+
+// RootsProvider is implemented by adapters offering roots to the server
+type RootsProvider interface {
+	// SetRoots sets the roots listed to the server. They must be set before
+	// connecting, as the roots capability is only advertised to the server
+	// when roots are set.
+	SetRoots(roots *Roots)
+
+	// NotifyRootsChanged sends notifications/roots/list_changed after the
+	// roots were changed, so the server lists them again
+	NotifyRootsChanged(ctx context.Context) error
+}
+
+// SetRoots sets the roots listed to the server
+func (b *BaseAdapter) SetRoots(roots *Roots) {
+	b.roots = roots
+}
+
+// NotifyRootsChanged tells the server that the roots changed
+func (b *BaseAdapter) NotifyRootsChanged(ctx context.Context) error {
+	if !b.connected || b.transport == nil {
+		return fmt.Errorf("not connected to server")
+	}
+
+	notification := mcp.JSONRPCNotification{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		Notification: mcp.Notification{
+			Method: mcp.MethodNotificationRootsListChanged,
+		},
+	}
+	if err := b.transport.SendNotification(ctx, notification); err != nil {
+		return fmt.Errorf("failed to send roots list change: %w", err)
+	}
+	return nil
+}
+
+// ParseRoot creates a root from a file:// URI or a local path, which is made
+// absolute. The root is named after the last element of its path.
+func ParseRoot(pathOrURI string) (mcp.Root, error) {
+	uri, err := RootURI(pathOrURI)
+	if err != nil {
+		return mcp.Root{}, err
+	}
+
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return mcp.Root{}, fmt.Errorf("invalid root URI %s: %w", uri, err)
+	}
+	return mcp.Root{URI: uri, Name: path.Base(parsed.Path)}, nil
+}
+
+// RootURI returns the file:// URI of a local path, or the URI itself when
+// given one. Roots are limited to file:// URIs by the MCP specification.
+func RootURI(pathOrURI string) (string, error) {
+	if strings.Contains(pathOrURI, "://") {
+		if !strings.HasPrefix(pathOrURI, "file://") {
+			return "", fmt.Errorf("invalid root %s: only file:// URIs are supported", pathOrURI)
+		}
+		return pathOrURI, nil
+	}
+
+	absolute, err := filepath.Abs(pathOrURI)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve root %s: %w", pathOrURI, err)
+	}
+	// Windows paths start with a drive letter, which follows the slash of
+	// the URI path
+	uriPath := filepath.ToSlash(absolute)
+	if !strings.HasPrefix(uriPath, "/") {
+		uriPath = "/" + uriPath
+	}
+	return (&url.URL{Scheme: "file", Path: uriPath}).String(), nil
+}
@@ -0,0 +1,106 @@
+package adapter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRootsServer creates a server whose "roots" tool returns the URIs of the
+// roots listed by the client, one per line. Root list changes are sent to
+// changed.
+func newRootsServer(changed chan<- struct{}) *server.MCPServer {
+	mcpServer := server.NewMCPServer("roots-test", "1.0.0", server.WithRoots())
+	mcpServer.AddNotificationHandler(mcp.MethodNotificationRootsListChanged, func(ctx context.Context, notification mcp.JSONRPCNotification) {
+		changed <- struct{}{}
+	})
+	mcpServer.AddTool(mcp.NewTool("roots"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := mcpServer.RequestRoots(ctx, mcp.ListRootsRequest{})
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		uris := make([]string, len(result.Roots))
+		for i, root := range result.Roots {
+			uris[i] = root.URI
+		}
+		return mcp.NewToolResultText(strings.Join(uris, "\n")), nil
+	})
+	return mcpServer
+}
+
+func TestRoots(t *testing.T) {
+	changed := make(chan struct{}, 1)
+	testServer := server.NewTestStreamableHTTPServer(newRootsServer(changed))
+	defer testServer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	adapter, err := NewAdapter(AdapterTypeAuto, Config{ServerURL: testServer.URL + "/mcp", Timeout: 5 * time.Second})
+	require.NoError(t, err)
+	roots := NewRoots(mcp.Root{URI: "file:///src/app", Name: "app"})
+	adapter.SetRoots(roots)
+
+	require.NoError(t, adapter.Connect(ctx))
+	defer func() { _ = adapter.Disconnect() }()
+
+	listed := func() string {
+		result, err := adapter.CallTool(ctx, "roots", nil)
+		require.NoError(t, err)
+		require.False(t, result.IsError, "%v", result.Content)
+		return result.Content[0].(mcp.TextContent).Text
+	}
+	assert.Equal(t, "file:///src/app", listed())
+
+	assert.True(t, roots.Add(mcp.Root{URI: "file:///src/lib", Name: "lib"}))
+	require.NoError(t, adapter.NotifyRootsChanged(ctx))
+	select {
+	case <-changed:
+	case <-ctx.Done():
+		t.Fatal("roots list change not received")
+	}
+	assert.Equal(t, "file:///src/app\nfile:///src/lib", listed())
+}
+
+func TestRootsList(t *testing.T) {
+	roots := NewRoots(mcp.Root{URI: "file:///a", Name: "a"})
+
+	assert.False(t, roots.Add(mcp.Root{URI: "file:///a", Name: "other"}))
+	assert.True(t, roots.Add(mcp.Root{URI: "file:///b", Name: "b"}))
+	assert.True(t, roots.Remove("file:///a"))
+	assert.False(t, roots.Remove("file:///a"))
+
+	result, err := roots.ListRoots(context.Background(), mcp.ListRootsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []mcp.Root{{URI: "file:///b", Name: "b"}}, result.Roots)
+}
+
+func TestParseRoot(t *testing.T) {
+	root, err := ParseRoot("file:///home/user/project")
+	require.NoError(t, err)
+	assert.Equal(t, mcp.Root{URI: "file:///home/user/project", Name: "project"}, root)
+
+	_, err = ParseRoot("https://example.com/project")
+	assert.ErrorContains(t, err, "only file:// URIs are supported")
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "my project"), 0o755))
+	t.Chdir(dir)
+
+	root, err = ParseRoot("my project")
+	require.NoError(t, err)
+	assert.Equal(t, "file://"+filepath.ToSlash(dir)+"/my%20project", root.URI)
+	assert.Equal(t, "my project", root.Name)
+}
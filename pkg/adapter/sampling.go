@@ -0,0 +1,43 @@
+package adapter
+
+import (
+	"context"
+
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// SamplingHandler answers the sampling/createMessage requests through which
+// a server asks the client for an LLM completion. Returning an error rejects
+// the request.
+type SamplingHandler interface {
+	CreateMessage(ctx context.Context, request mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error)
+}
+
+// Sampler is implemented by adapters answering server sampling requests
+type Sampler interface {
+	// SetSamplingHandler sets the handler of sampling requests. It must be
+	// set before connecting, as the sampling capability is only advertised
+	// to the server when a handler is set.
+	SetSamplingHandler(handler SamplingHandler)
+}
+
+// SetSamplingHandler sets the handler of sampling requests
+func (b *BaseAdapter) SetSamplingHandler(handler SamplingHandler) {
+	b.sampling = handler
+}
+
+// clientOptions enables the client features for which a handler is set
+func (b *BaseAdapter) clientOptions() []mcpclient.ClientOption {
+	var options []mcpclient.ClientOption
+	if b.sampling != nil {
+		options = append(options, mcpclient.WithSamplingHandler(b.sampling))
+	}
+	if b.roots != nil {
+		options = append(options, mcpclient.WithRootsHandler(b.roots))
+	}
+	if b.elicitation != nil {
+		options = append(options, mcpclient.WithElicitationHandler(b.elicitation))
+	}
+	return options
+}
@@ -0,0 +1,88 @@
+package adapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// samplingFunc adapts a function to SamplingHandler
+type samplingFunc func(ctx context.Context, request mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error)
+
+func (f samplingFunc) CreateMessage(ctx context.Context, request mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error) {
+	return f(ctx, request)
+}
+
+// newSamplingServer creates a server whose "ask" tool returns the answer of
+// the client to a sampling request with its question
+func newSamplingServer() *server.MCPServer {
+	mcpServer := server.NewMCPServer("sampling-test", "1.0.0")
+	mcpServer.EnableSampling()
+	mcpServer.AddTool(mcp.NewTool("ask", mcp.WithString("question")), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		samplingRequest := mcp.CreateMessageRequest{}
+		samplingRequest.Messages = []mcp.SamplingMessage{
+			{Role: mcp.RoleUser, Content: mcp.NewTextContent(request.GetString("question", ""))},
+		}
+		samplingRequest.MaxTokens = 100
+
+		result, err := mcpServer.RequestSampling(ctx, samplingRequest)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		text, _ := result.Content.(mcp.TextContent)
+		return mcp.NewToolResultText(result.Model + ": " + text.Text), nil
+	})
+	return mcpServer
+}
+
+func TestSampling(t *testing.T) {
+	testServer := server.NewTestStreamableHTTPServer(newSamplingServer())
+	defer testServer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ask := func(t *testing.T, adapter ServerAdapter) *mcp.CallToolResult {
+		require.NoError(t, adapter.Connect(ctx))
+		defer func() { _ = adapter.Disconnect() }()
+
+		result, err := adapter.CallTool(ctx, "ask", map[string]any{"question": "What is MCP?"})
+		require.NoError(t, err)
+		require.Len(t, result.Content, 1)
+		return result
+	}
+
+	t.Run("with handler", func(t *testing.T) {
+		adapter, err := NewAdapter(AdapterTypeAuto, Config{ServerURL: testServer.URL + "/mcp", Timeout: 5 * time.Second})
+		require.NoError(t, err)
+
+		var received mcp.CreateMessageRequest
+		adapter.SetSamplingHandler(samplingFunc(func(ctx context.Context, request mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error) {
+			received = request
+			return &mcp.CreateMessageResult{
+				SamplingMessage: mcp.SamplingMessage{Role: mcp.RoleAssistant, Content: mcp.NewTextContent("A protocol")},
+				Model:           "test-model",
+			}, nil
+		}))
+
+		result := ask(t, adapter)
+		assert.False(t, result.IsError)
+		assert.Equal(t, mcp.NewTextContent("test-model: A protocol"), result.Content[0])
+		require.Len(t, received.Messages, 1)
+		assert.Equal(t, mcp.NewTextContent("What is MCP?"), received.Messages[0].Content)
+		assert.Equal(t, 100, received.MaxTokens)
+	})
+
+	t.Run("without handler", func(t *testing.T) {
+		adapter, err := NewHTTPAdapter(Config{ServerURL: testServer.URL + "/mcp", Timeout: 5 * time.Second})
+		require.NoError(t, err)
+
+		result := ask(t, adapter)
+		assert.True(t, result.IsError)
+	})
+}
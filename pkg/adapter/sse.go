@@ -0,0 +1,125 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// sseProtocolVersion is the protocol version of the HTTP+SSE transport. Later
+// versions replaced it with streamable HTTP, so servers using it may reject
+// or misread newer versions.
+const sseProtocolVersion = "2024-11-05"
+
+// SSEAdapter implements ServerAdapter for servers using the legacy HTTP+SSE
+// transport (protocol version 2024-11-05). The client keeps a GET event
+// stream open to receive messages and sends requests to the POST endpoint
+// announced by the server in its first "endpoint" event.
+//
+// Apart from connecting, the adapter behaves like the HTTP adapter, so the
+// request methods are shared with it.
+type SSEAdapter struct {
+	HTTPAdapter
+	cancelStream context.CancelFunc
+}
+
+// NewSSEAdapter creates a new SSE adapter
+func NewSSEAdapter(config Config) (*SSEAdapter, error) {
+	if config.ServerURL == "" {
+		return nil, fmt.Errorf("server URL is required for SSE adapter")
+	}
+
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	return &SSEAdapter{
+		HTTPAdapter: HTTPAdapter{
+			BaseAdapter: BaseAdapter{
+				config: config,
+			},
+		},
+	}, nil
+}
+
+// Connect opens the SSE event stream and initializes the MCP session
+func (s *SSEAdapter) Connect(ctx context.Context) error {
+	if s.connected {
+		return fmt.Errorf("already connected")
+	}
+
+	s.logf("Connecting to MCP server via SSE: %s", s.config.ServerURL)
+
+	headers, err := s.config.httpHeaders()
+	if err != nil {
+		return err
+	}
+
+	trans, err := transport.NewSSE(s.config.ServerURL, transport.WithHeaders(headers), transport.WithSSELogger(transportLogger{&s.BaseAdapter}))
+	if err != nil {
+		return fmt.Errorf("failed to create SSE client: %w", err)
+	}
+	trans.SetConnectionLostHandler(func(err error) {
+		s.connectionLost(fmt.Errorf("event stream closed: %w", err))
+	})
+	client := s.newClient(trans)
+
+	// The event stream must outlive the connect context, so it is started
+	// with its own context that is only bound to ctx until the server has
+	// announced its message endpoint. Disconnect closes the stream.
+	streamCtx, cancelStream := context.WithCancel(context.Background())
+	stop := context.AfterFunc(ctx, cancelStream)
+	err = client.Start(streamCtx)
+	stop()
+	if err != nil {
+		cancelStream()
+		return fmt.Errorf("failed to open SSE stream: %w", err)
+	}
+
+	s.listen(client)
+	s.client = client
+	s.cancelStream = cancelStream
+	s.logf("Received message endpoint: %s", trans.GetEndpoint())
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	// Initialize the connection
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = sseProtocolVersion
+	initRequest.Params.ClientInfo = mcp.Implementation{
+		Name:    "mcp-cli-adapter",
+		Version: "1.0.0",
+	}
+
+	result, err := s.client.Initialize(ctx, initRequest)
+	if err != nil {
+		if err := s.client.Close(); err != nil {
+			// Log the error but don't return it since this is likely in a cleanup context
+			fmt.Fprintf(os.Stderr, "Warning: failed to close SSE client: %v\n", err)
+		}
+		s.cancelStream()
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+
+	s.setConnected(true)
+	s.setServerInfo(&result.ServerInfo)
+	s.logf("Successfully connected to server: %s %s", result.ServerInfo.Name, result.ServerInfo.Version)
+
+	return nil
+}
+
+// Disconnect closes the SSE event stream
+func (s *SSEAdapter) Disconnect() error {
+	if !s.connected {
+		return nil
+	}
+
+	err := s.HTTPAdapter.Disconnect()
+	s.cancelStream()
+	return err
+}
@@ -0,0 +1,161 @@
+package adapter
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+)
+
+const (
+	// stderrLines is the number of lines of stderr kept for a server process
+	stderrLines = 100
+
+	// stderrErrorLines is the number of lines of stderr added to errors
+	stderrErrorLines = 10
+
+	// stderrLineLength is the length after which a line is kept without
+	// waiting for its newline
+	stderrLineLength = 4096
+)
+
+// StderrProvider is implemented by the adapters of servers run as a local
+// process, which keep the last lines the server wrote to stderr
+type StderrProvider interface {
+	// Stderr returns the last lines the server wrote to stderr, oldest
+	// first, with the configured secrets masked
+	Stderr() []string
+}
+
+// stderrBuffer keeps the last lines written to the stderr of a server in a
+// ring buffer. It is safe for concurrent use.
+type stderrBuffer struct {
+	mu    sync.Mutex
+	lines []string
+	size  int
+	// start is the index of the oldest line once the buffer is full
+	start int
+	// total is the number of complete lines written
+	total int
+	// partial is the last line while it has no newline
+	partial []byte
+}
+
+func newStderrBuffer(size int) *stderrBuffer {
+	return &stderrBuffer{lines: make([]string, 0, size), size: size}
+}
+
+// Write adds the lines of p to the buffer, dropping the oldest ones
+func (b *stderrBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.partial = append(b.partial, p...)
+	for {
+		i := bytes.IndexByte(b.partial, '\n')
+		if i < 0 {
+			break
+		}
+		b.add(strings.TrimSuffix(string(b.partial[:i]), "\r"))
+		b.partial = b.partial[i+1:]
+	}
+	if len(b.partial) > stderrLineLength {
+		b.add(string(b.partial))
+		b.partial = nil
+	}
+	return len(p), nil
+}
+
+func (b *stderrBuffer) add(line string) {
+	if len(b.lines) < b.size {
+		b.lines = append(b.lines, line)
+	} else {
+		b.lines[b.start] = line
+		b.start = (b.start + 1) % b.size
+	}
+	b.total++
+}
+
+// mark returns the number of lines written so far, to be passed to since
+func (b *stderrBuffer) mark() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.total
+}
+
+// since returns at most n of the last lines written after mark, oldest
+// first. A line without a newline yet is included.
+func (b *stderrBuffer) since(mark, n int) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	count := b.total - mark
+	if len(b.partial) > 0 {
+		count++
+	}
+	return b.tail(min(count, n))
+}
+
+// tail returns the last n lines, including a line without a newline yet
+func (b *stderrBuffer) tail(n int) []string {
+	lines := make([]string, 0, len(b.lines)+1)
+	lines = append(lines, b.lines[b.start:]...)
+	lines = append(lines, b.lines[:b.start]...)
+	if len(b.partial) > 0 {
+		lines = append(lines, strings.TrimSuffix(string(b.partial), "\r"))
+	}
+	if n < len(lines) {
+		lines = lines[len(lines)-max(n, 0):]
+	}
+	return lines
+}
+
+// all returns the lines kept, oldest first
+func (b *stderrBuffer) all() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.tail(b.size)
+}
+
+// capture reads r until it is closed, keeping its lines and copying it to
+// log when set. A failing log is dropped, since the server blocks when its
+// stderr is not read.
+func (b *stderrBuffer) capture(r io.Reader, log io.Writer, logf func(format string, args ...any)) {
+	buf := make([]byte, 4096)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			_, _ = b.Write(buf[:n])
+			if log != nil {
+				if _, err := log.Write(buf[:n]); err != nil {
+					logf("Warning: failed to write the stderr log of the server: %v", err)
+					log = nil
+				}
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// stderrError is an error of a server process with the last lines it wrote
+// to stderr
+type stderrError struct {
+	err   error
+	lines []string
+}
+
+func (e *stderrError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.err.Error())
+	b.WriteString("\nlast output of the server on stderr:")
+	for _, line := range e.lines {
+		b.WriteString("\n  " + line)
+	}
+	return b.String()
+}
+
+func (e *stderrError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,79 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStderrBuffer(t *testing.T) {
+	b := newStderrBuffer(3)
+	assert.Empty(t, b.all())
+
+	_, _ = b.Write([]byte("one\r\ntw"))
+	assert.Equal(t, []string{"one", "tw"}, b.all())
+
+	mark := b.mark()
+	_, _ = b.Write([]byte("o\nthree\nfour\nfi"))
+	assert.Equal(t, []string{"three", "four", "fi"}, b.all())
+	assert.Equal(t, []string{"four", "fi"}, b.since(mark, 2))
+	// The line started before mark is included
+	assert.Equal(t, []string{"two", "three", "four", "fi"}, b.since(mark, 10))
+
+	_, _ = b.Write([]byte("ve\n"))
+	assert.Equal(t, []string{"three", "four", "five"}, b.all())
+	assert.Empty(t, b.since(b.mark(), 10))
+}
+
+func TestStderrError(t *testing.T) {
+	cause := errors.New("process exited unexpectedly")
+	err := error(&stderrError{err: cause, lines: []string{"starting", "missing API_KEY"}})
+	assert.Equal(t, "process exited unexpectedly\nlast output of the server on stderr:\n  starting\n  missing API_KEY", err.Error())
+	assert.ErrorIs(t, err, cause)
+}
+
+func TestStdioAdapterStderr(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the server")
+	}
+
+	logPath := filepath.Join(t.TempDir(), "server.log")
+	var script string
+	for i := range 15 {
+		script += fmt.Sprintf("echo line %d >&2; ", i)
+	}
+	adapter, err := NewStdioAdapter(Config{
+		Command:   "sh",
+		Args:      []string{"-c", script + "echo 'token s3cr3t rejected' >&2; exit 1"},
+		StderrLog: logPath,
+		Secrets:   []string{"s3cr3t"},
+		Timeout:   5 * time.Second,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err = adapter.Connect(ctx)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "process exited unexpectedly")
+	assert.ErrorContains(t, err, "last output of the server on stderr:\n  line 6\n")
+	assert.ErrorContains(t, err, "line 14\n  token **** rejected")
+	assert.NotContains(t, err.Error(), "line 5\n")
+
+	lines := adapter.Stderr()
+	assert.Len(t, lines, 16)
+	assert.Equal(t, "token **** rejected", lines[15])
+
+	log, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(log), "line 0\n")
+	assert.Contains(t, string(log), "token s3cr3t rejected\n")
+}
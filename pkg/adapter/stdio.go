@@ -2,12 +2,17 @@ package adapter
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"iter"
 	"os"
+	"os/exec"
 	"strings"
 	"time"
 
 	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -15,6 +20,13 @@ import (
 type StdioAdapter struct {
 	BaseAdapter
 	client mcpclient.MCPClient
+
+	// stderr keeps the last lines the server wrote to stderr, and
+	// stderrDone is closed once the server closed its stderr
+	stderr     *stderrBuffer
+	stderrDone chan struct{}
+	// stderrLog is the file given by Config.StderrLog
+	stderrLog io.WriteCloser
 }
 
 // NewStdioAdapter creates a new stdio adapter
@@ -41,25 +53,38 @@ func (s *StdioAdapter) Connect(ctx context.Context) error {
 	}
 
 	s.logf("Connecting to MCP server via stdio: %s %v", s.config.Command, s.config.Args)
-	client, err := mcpclient.NewStdioMCPClient(
-		s.config.Command,
-		s.config.Env,
-		s.config.Args...,
-	)
-	if err != nil {
+	args, env := s.config.commandLine()
+	if s.config.StderrLog != "" {
+		log, err := os.OpenFile(s.config.StderrLog, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to open stderr log: %w", err)
+		}
+		s.stderrLog = log
+	}
+
+	trans := transport.NewStdioWithOptions(s.config.Command, env, args, transport.WithCommandFunc(s.command), transport.WithCommandLogger(transportLogger{&s.BaseAdapter}))
+	client := s.newClient(trans)
+	// The transport passes its context to the handlers of server requests,
+	// so it must outlive ctx
+	if err := client.Start(context.Background()); err != nil {
+		s.closeStderrLog()
 		return fmt.Errorf("failed to create stdio client: %w", err)
 	}
+	s.captureStderr(trans.Stderr())
 
 	// Log client for debugging
 	s.logf("Created stdio client: %+v", client)
 
+	s.listen(client)
 	s.client = client
 
 	// Wait and check if process is still alive
 	if err := s.waitForProcessReady(ctx); err != nil {
+		err = s.withStderr(err, 0)
 		if closeErr := s.client.Close(); closeErr != nil {
 			s.logf("Warning: failed to close stdio client during cleanup: %v", closeErr)
 		}
+		s.closeStderrLog()
 		return err
 	}
 
@@ -70,13 +95,6 @@ func (s *StdioAdapter) Connect(ctx context.Context) error {
 		Name:    "mcp-cli-adapter",
 		Version: "1.0.0",
 	}
-	initRequest.Params.Capabilities = mcp.ClientCapabilities{
-		Roots: &struct {
-			ListChanged bool `json:"listChanged,omitempty"`
-		}{
-			ListChanged: true,
-		},
-	}
 
 	s.logf("Sending initialize request with timeout: %v", s.config.Timeout)
 	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
@@ -85,11 +103,13 @@ func (s *StdioAdapter) Connect(ctx context.Context) error {
 	result, err := s.client.Initialize(ctx, initRequest)
 	if err != nil {
 		s.logf("Initialize failed: %v", err)
+		err = s.withStderr(fmt.Errorf("failed to initialize: %w", err), 0)
 		if err := s.client.Close(); err != nil {
 			// Log the error but don't return it since this is likely in a cleanup context
 			fmt.Fprintf(os.Stderr, "Warning: failed to close stdio client: %v\n", err)
 		}
-		return fmt.Errorf("failed to initialize: %w", err)
+		s.closeStderrLog()
+		return err
 	}
 
 	s.setConnected(true)
@@ -106,58 +126,138 @@ func (s *StdioAdapter) Disconnect() error {
 	}
 
 	s.logf("Disconnecting from MCP server")
+	s.stopMonitor()
 	err := s.client.Close()
+	s.closeStderrLog()
 	s.setConnected(false)
 	s.setServerInfo(nil)
 	return err
 }
 
-// ListTools returns available tools from the server
-func (s *StdioAdapter) ListTools(ctx context.Context) ([]mcp.Tool, error) {
-	if !s.connected {
-		return nil, fmt.Errorf("not connected to server")
-	}
+// command creates the server process, in its own process group. It is not
+// bound to the context of the transport, which ends with the connection.
+func (s *StdioAdapter) command(ctx context.Context, command string, env []string, args []string) (*exec.Cmd, error) {
+	cmd := exec.Command(command, args...)
+	cmd.Env = append(os.Environ(), env...)
+	detachProcess(cmd)
+	return cmd, nil
+}
 
-	request := mcp.ListToolsRequest{}
-	result, err := s.client.ListTools(ctx, request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list tools: %w", err)
+// captureStderr reads the stderr of the server in the background, so the
+// server does not block once the pipe is full
+func (s *StdioAdapter) captureStderr(r io.Reader) {
+	buffer, done, log := newStderrBuffer(stderrLines), make(chan struct{}), s.stderrLog
+	s.stderr, s.stderrDone = buffer, done
+	go func() {
+		defer close(done)
+		buffer.capture(r, log, s.logf)
+		// stderr is closed when the process exits
+		s.connectionLost(errors.New("server process exited"))
+	}()
+}
+
+// closeStderrLog closes the stderr log once the output of the closed
+// server is copied to it
+func (s *StdioAdapter) closeStderrLog() {
+	if s.stderrLog == nil {
+		return
 	}
+	if s.stderrDone != nil {
+		select {
+		case <-s.stderrDone:
+		case <-time.After(time.Second):
+		}
+	}
+	if err := s.stderrLog.Close(); err != nil {
+		s.logf("Warning: failed to close stderr log: %v", err)
+	}
+	s.stderrLog = nil
+}
 
-	return result.Tools, nil
+// withStderr adds to err the last lines the server wrote to stderr after
+// mark. When the server exited, its last output is waited for briefly.
+func (s *StdioAdapter) withStderr(err error, mark int) error {
+	if s.stderr == nil {
+		return err
+	}
+	if isProcessExitError(err) {
+		select {
+		case <-s.stderrDone:
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	lines := s.stderr.since(mark, stderrErrorLines)
+	if len(lines) == 0 {
+		return err
+	}
+	for i, line := range lines {
+		lines[i] = s.config.mask(line)
+	}
+	return &stderrError{err: err, lines: lines}
 }
 
-// CallTool executes a tool on the server
-func (s *StdioAdapter) CallTool(ctx context.Context, name string, arguments map[string]any) (*mcp.CallToolResult, error) {
-	if !s.connected {
-		return nil, fmt.Errorf("not connected to server")
+// Stderr returns the last lines the server wrote to stderr
+func (s *StdioAdapter) Stderr() []string {
+	if s.stderr == nil {
+		return nil
 	}
+	lines := s.stderr.all()
+	for i, line := range lines {
+		lines[i] = s.config.mask(line)
+	}
+	return lines
+}
 
-	request := mcp.CallToolRequest{}
-	request.Params.Name = name
-	request.Params.Arguments = arguments
+// ListTools returns available tools from the server, following cursors
+// up to the pagination limits
+func (s *StdioAdapter) ListTools(ctx context.Context) ([]mcp.Tool, error) {
+	return collect(&s.BaseAdapter, "tools", s.ToolPages(ctx))
+}
 
-	result, err := s.client.CallTool(ctx, request)
+// ToolPages iterates over the pages of the tool listing
+func (s *StdioAdapter) ToolPages(ctx context.Context) iter.Seq2[[]mcp.Tool, error] {
+	return s.toolPages(ctx, s.client)
+}
+
+// CallTool executes a tool on the server, cancelling it when ctx is done.
+// Errors include what the server wrote to stderr during the call.
+func (s *StdioAdapter) CallTool(ctx context.Context, name string, arguments map[string]any) (*mcp.CallToolResult, error) {
+	var mark int
+	if s.stderr != nil {
+		mark = s.stderr.mark()
+	}
+	result, err := s.callTool(ctx, s.client, name, arguments)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call tool %s: %w", name, err)
+		return nil, s.withStderr(err, mark)
 	}
-
 	return result, nil
 }
 
-// ListResources returns available resources from the server
+// SetLogLevel sets the minimum level of the log messages sent by the server
+func (s *StdioAdapter) SetLogLevel(ctx context.Context, level mcp.LoggingLevel) error {
+	return s.setLogLevel(ctx, s.client, level)
+}
+
+// ListResources returns available resources from the server, following cursors
+// up to the pagination limits
 func (s *StdioAdapter) ListResources(ctx context.Context) ([]mcp.Resource, error) {
-	if !s.connected {
-		return nil, fmt.Errorf("not connected to server")
-	}
+	return collect(&s.BaseAdapter, "resources", s.ResourcePages(ctx))
+}
 
-	request := mcp.ListResourcesRequest{}
-	result, err := s.client.ListResources(ctx, request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list resources: %w", err)
-	}
+// ResourcePages iterates over the pages of the resource listing
+func (s *StdioAdapter) ResourcePages(ctx context.Context) iter.Seq2[[]mcp.Resource, error] {
+	return s.resourcePages(ctx, s.client)
+}
+
+// ListResourceTemplates returns available resource templates from the
+// server, following cursors up to the pagination limits
+func (s *StdioAdapter) ListResourceTemplates(ctx context.Context) ([]mcp.ResourceTemplate, error) {
+	return collect(&s.BaseAdapter, "resource templates", s.ResourceTemplatePages(ctx))
+}
 
-	return result.Resources, nil
+// ResourceTemplatePages iterates over the pages of the resource template listing
+func (s *StdioAdapter) ResourceTemplatePages(ctx context.Context) iter.Seq2[[]mcp.ResourceTemplate, error] {
+	return s.resourceTemplatePages(ctx, s.client)
 }
 
 // ReadResource reads a specific resource
@@ -177,19 +277,25 @@ func (s *StdioAdapter) ReadResource(ctx context.Context, uri string) (*mcp.ReadR
 	return result, nil
 }
 
-// ListPrompts returns available prompts from the server
-func (s *StdioAdapter) ListPrompts(ctx context.Context) ([]mcp.Prompt, error) {
-	if !s.connected {
-		return nil, fmt.Errorf("not connected to server")
-	}
+// Subscribe subscribes to the updates of a resource
+func (s *StdioAdapter) Subscribe(ctx context.Context, uri string) error {
+	return s.subscribe(ctx, s.client, uri)
+}
 
-	request := mcp.ListPromptsRequest{}
-	result, err := s.client.ListPrompts(ctx, request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list prompts: %w", err)
-	}
+// Unsubscribe cancels the subscription to the updates of a resource
+func (s *StdioAdapter) Unsubscribe(ctx context.Context, uri string) error {
+	return s.unsubscribe(ctx, s.client, uri)
+}
+
+// ListPrompts returns available prompts from the server, following cursors
+// up to the pagination limits
+func (s *StdioAdapter) ListPrompts(ctx context.Context) ([]mcp.Prompt, error) {
+	return collect(&s.BaseAdapter, "prompts", s.PromptPages(ctx))
+}
 
-	return result.Prompts, nil
+// PromptPages iterates over the pages of the prompt listing
+func (s *StdioAdapter) PromptPages(ctx context.Context) iter.Seq2[[]mcp.Prompt, error] {
+	return s.promptPages(ctx, s.client)
 }
 
 // GetPrompt retrieves a specific prompt
@@ -210,6 +316,12 @@ func (s *StdioAdapter) GetPrompt(ctx context.Context, name string, arguments map
 	return result, nil
 }
 
+// Complete asks the server for the values completing a prompt argument or a
+// resource template variable
+func (s *StdioAdapter) Complete(ctx context.Context, ref any, argument mcp.CompleteArgument, arguments map[string]string) (*mcp.Completion, error) {
+	return s.complete(ctx, s.client, ref, argument, arguments)
+}
+
 func (s *StdioAdapter) waitForProcessReady(ctx context.Context) error {
 	s.logf("Waiting for process to be ready...")
 
@@ -262,6 +374,10 @@ func isProcessExitError(err error) bool {
 	if err == nil {
 		return false
 	}
+	// The transport closes once it reads the end of the output of the process
+	if errors.Is(err, transport.ErrTransportClosed) {
+		return true
+	}
 	errStr := err.Error()
 	return strings.Contains(errStr, "process") ||
 		strings.Contains(errStr, "exit") ||
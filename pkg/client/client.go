@@ -131,9 +131,11 @@ func (c *Client) GetServer(id string) (*ServerDetail, error) {
 }
 
 // GetServerByName fetches detailed information about a server by name
-// This method searches through all servers to find a match by name
+// This method searches through all servers to find a match by name. When
+// several versions of the server are listed, the latest one is returned.
 func (c *Client) GetServerByName(name string) (*ServerDetail, error) {
 	var cursor string
+	var match *models.Server
 
 	// Search through all pages to find the server
 	for {
@@ -144,10 +146,16 @@ func (c *Client) GetServerByName(name string) (*ServerDetail, error) {
 
 		// Check each server in this page
 		for _, server := range response.Servers {
-			if server.Name == name {
-				// Found the server, now get full details
+			if server.Name != name {
+				continue
+			}
+			if server.VersionDetail.IsLatest {
+				// Found the latest version, now get full details
 				return c.GetServer(server.ID)
 			}
+			if match == nil {
+				match = &server
+			}
 		}
 
 		// If no more pages, stop searching
@@ -157,6 +165,9 @@ func (c *Client) GetServerByName(name string) (*ServerDetail, error) {
 		cursor = response.Metadata.NextCursor
 	}
 
+	if match != nil {
+		return c.GetServer(match.ID)
+	}
 	return nil, fmt.Errorf("server with name '%s' not found", name)
 }
 
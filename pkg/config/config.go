@@ -0,0 +1,223 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Server is a named server entry of the configuration file
+type Server struct {
+	Type    string            `yaml:"type,omitempty" json:"type,omitempty"`
+	Command string            `yaml:"command,omitempty" json:"command,omitempty"`
+	Args    []string          `yaml:"args,omitempty" json:"args,omitempty"`
+	Env     map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+	URL     string            `yaml:"url,omitempty" json:"url,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+	Timeout string            `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+// File is the server configuration file
+type File struct {
+	Servers map[string]Server `yaml:"servers"`
+
+	path string
+	// doc is the document read by Load, updated by Save so the comments and
+	// formatting of the file are kept
+	doc *yaml.Node
+}
+
+// DefaultPath returns the location of the server configuration file,
+// $XDG_CONFIG_HOME/mcp-cli/servers.yaml or ~/.config/mcp-cli/servers.yaml
+func DefaultPath() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "mcp-cli", "servers.yaml"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "mcp-cli", "servers.yaml"), nil
+}
+
+// Load reads the configuration file at path, or at DefaultPath() when path
+// is empty. A missing file is an empty configuration.
+func Load(path string) (*File, error) {
+	if path == "" {
+		var err error
+		if path, err = DefaultPath(); err != nil {
+			return nil, err
+		}
+	}
+
+	file := &File{Servers: map[string]Server{}, path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return file, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	if doc.Kind != 0 {
+		if err := doc.Decode(file); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+		file.doc = &doc
+	}
+	if file.Servers == nil {
+		file.Servers = map[string]Server{}
+	}
+
+	for _, name := range file.Names() {
+		if err := file.Servers[name].Validate(); err != nil {
+			return nil, fmt.Errorf("invalid server %q in %s: %w", name, path, err)
+		}
+	}
+
+	return file, nil
+}
+
+// Path returns the location of the configuration file
+func (f *File) Path() string {
+	return f.path
+}
+
+// Save writes the configuration file. It can hold credentials in headers
+// and environment variables, so it is only readable by the current user.
+// Only the entries which changed are rewritten, the comments, order and
+// formatting of the others are kept.
+func (f *File) Save() error {
+	data, err := f.encode()
+	if err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(f.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// Names returns the names of the configured servers in sorted order
+func (f *File) Names() []string {
+	names := make([]string, 0, len(f.Servers))
+	for name := range f.Servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Server returns the server entry with the given name
+func (f *File) Server(name string) (Server, error) {
+	server, ok := f.Servers[name]
+	if !ok {
+		return Server{}, fmt.Errorf("server %q not found in %s", name, f.path)
+	}
+	return server, nil
+}
+
+// transportTypes are the values accepted for the type of an entry
+var transportTypes = []string{"stdio", "http", "streamable", "sse", "auto"}
+
+// TransportType returns the transport of the entry. When not set, entries
+// with a URL detect the transport of the server and others use stdio.
+func (s Server) TransportType() string {
+	switch {
+	case s.Type != "":
+		return s.Type
+	case s.URL != "":
+		return "auto"
+	default:
+		return "stdio"
+	}
+}
+
+// Validate checks that the entry has what its transport needs
+func (s Server) Validate() error {
+	if !slices.Contains(transportTypes, s.TransportType()) {
+		return fmt.Errorf("unknown type %q (accepted: %s)", s.Type, strings.Join(transportTypes, ", "))
+	}
+
+	if s.TransportType() == "stdio" {
+		if s.Command == "" {
+			return errors.New("command is required for stdio servers")
+		}
+	} else if s.URL == "" {
+		return fmt.Errorf("url is required for %s servers", s.TransportType())
+	}
+
+	if s.Timeout != "" {
+		if _, err := time.ParseDuration(s.Timeout); err != nil {
+			return fmt.Errorf("invalid timeout: %w", err)
+		}
+	}
+	return nil
+}
+
+// Target returns the command line or URL of the server for display
+func (s Server) Target() string {
+	if s.TransportType() == "stdio" {
+		return strings.Join(append([]string{s.Command}, s.Args...), " ")
+	}
+	return s.URL
+}
+
+// FactoryConfig converts the entry into the configuration map accepted by
+// adapter.AdapterFactory.CreateFromConfig
+func (s Server) FactoryConfig() map[string]interface{} {
+	config := map[string]interface{}{
+		"type": s.TransportType(),
+	}
+
+	if s.Command != "" {
+		command, args := s.Command, s.Args
+		// A command given as a single string is split like --command
+		if len(args) == 0 {
+			if parts := strings.Fields(command); len(parts) > 1 {
+				command, args = parts[0], parts[1:]
+			}
+		}
+		config["command"] = command
+		config["args"] = args
+		config["expand_env"] = true
+	}
+
+	if len(s.Env) > 0 {
+		env := make([]string, 0, len(s.Env))
+		for key, value := range s.Env {
+			env = append(env, key+"="+value)
+		}
+		sort.Strings(env)
+		config["env"] = env
+	}
+
+	if s.URL != "" {
+		config["url"] = s.URL
+	}
+	if len(s.Headers) > 0 {
+		config["headers"] = s.Headers
+	}
+	if s.Timeout != "" {
+		config["timeout"] = s.Timeout
+	}
+
+	return config
+}
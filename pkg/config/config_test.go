@@ -0,0 +1,170 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `servers:
+  filesystem:
+    command: npx
+    args: ["-y", "@modelcontextprotocol/server-filesystem", "/tmp"]
+    env:
+      DEBUG: "1"
+      HOME_DIR: /home/user
+  remote:
+    type: http
+    url: https://api.example.com/mcp
+    headers:
+      Authorization: Bearer ${API_TOKEN}
+    timeout: 45s
+  detected:
+    url: https://example.com/sse
+`
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "servers.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("named servers", func(t *testing.T) {
+		file, err := Load(writeConfig(t, testConfig))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"detected", "filesystem", "remote"}, file.Names())
+
+		server, err := file.Server("filesystem")
+		require.NoError(t, err)
+		assert.Equal(t, "stdio", server.TransportType())
+		assert.Equal(t, "npx -y @modelcontextprotocol/server-filesystem /tmp", server.Target())
+
+		server, err = file.Server("detected")
+		require.NoError(t, err)
+		assert.Equal(t, "auto", server.TransportType())
+
+		_, err = file.Server("missing")
+		assert.ErrorContains(t, err, `server "missing" not found`)
+	})
+
+	t.Run("missing file is empty", func(t *testing.T) {
+		file, err := Load(filepath.Join(t.TempDir(), "servers.yaml"))
+		require.NoError(t, err)
+		assert.Empty(t, file.Servers)
+	})
+
+	t.Run("default path", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+		path, err := DefaultPath()
+		require.NoError(t, err)
+		assert.Equal(t, "/tmp/xdg/mcp-cli/servers.yaml", path)
+	})
+
+	t.Run("invalid entries", func(t *testing.T) {
+		tests := map[string]string{
+			"servers:\n  a:\n    type: stdio\n":                   "command is required",
+			"servers:\n  a:\n    type: sse\n":                     "url is required for sse servers",
+			"servers:\n  a:\n    command: x\n    timeout: soon\n": "invalid timeout",
+			"servers:\n  a:\n    type: studio\n    url: x\n":      `unknown type "studio" (accepted: stdio, http, streamable, sse, auto)`,
+			"servers:\n  a:\n    type: stdoi\n    command: x\n":   `unknown type "stdoi"`,
+			"servers: [1, 2]\n":                                   "failed to parse config file",
+		}
+		for content, expected := range tests {
+			_, err := Load(writeConfig(t, content))
+			assert.ErrorContains(t, err, expected)
+		}
+	})
+}
+
+func TestSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "servers.yaml")
+	file, err := Load(path)
+	require.NoError(t, err)
+
+	file.Servers["echo"] = Server{Command: "echo-server", Env: map[string]string{"TOKEN": "secret"}}
+	require.NoError(t, file.Save())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	reloaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, file.Servers, reloaded.Servers)
+}
+
+func TestSaveKeepsFormatting(t *testing.T) {
+	path := writeConfig(t, `# Servers used by the team
+servers:
+  # Production API, ask ops for the token
+  remote:
+    url: https://api.example.com/mcp
+    headers:
+      Authorization: Bearer ${API_TOKEN} # from the vault
+  filesystem:
+    command: npx
+    args: ["-y", "@modelcontextprotocol/server-filesystem", "/tmp"]
+  old:
+    command: old-server
+`)
+	file, err := Load(path)
+	require.NoError(t, err)
+
+	file.Servers["filesystem"] = Server{Command: "npx", Args: []string{"-y", "@modelcontextprotocol/server-filesystem", "/srv"}}
+	file.Servers["echo"] = Server{Command: "echo-server"}
+	delete(file.Servers, "old")
+	require.NoError(t, file.Save())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `# Servers used by the team
+servers:
+  # Production API, ask ops for the token
+  remote:
+    url: https://api.example.com/mcp
+    headers:
+      Authorization: Bearer ${API_TOKEN} # from the vault
+  filesystem:
+    command: npx
+    args:
+      - -y
+      - '@modelcontextprotocol/server-filesystem'
+      - /srv
+  echo:
+    command: echo-server
+`, string(data))
+
+	reloaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, file.Servers, reloaded.Servers)
+}
+
+func TestFactoryConfig(t *testing.T) {
+	file, err := Load(writeConfig(t, testConfig))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"type":    "stdio",
+		"command": "npx",
+		"args":    []string{"-y", "@modelcontextprotocol/server-filesystem", "/tmp"},
+		"env":     []string{"DEBUG=1", "HOME_DIR=/home/user"},
+
+		"expand_env": true,
+	}, file.Servers["filesystem"].FactoryConfig())
+
+	assert.Equal(t, map[string]interface{}{
+		"type":    "http",
+		"url":     "https://api.example.com/mcp",
+		"headers": map[string]string{"Authorization": "Bearer ${API_TOKEN}"},
+		"timeout": "45s",
+	}, file.Servers["remote"].FactoryConfig())
+
+	// A command line in a single string is split like --command
+	config := Server{Command: "python server.py --port 8080"}.FactoryConfig()
+	assert.Equal(t, "python", config["command"])
+	assert.Equal(t, []string{"server.py", "--port", "8080"}, config["args"])
+}
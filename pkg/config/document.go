@@ -0,0 +1,103 @@
+package config
+
+import (
+	"bytes"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// encode returns the content of the configuration file. When it was read by
+// Load, the servers are updated in its document.
+func (f *File) encode() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	var err error
+	if root := f.root(); root != nil {
+		err = f.updateServers(root)
+		if err == nil {
+			err = encoder.Encode(f.doc)
+		}
+	} else {
+		err = encoder.Encode(f)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// root returns the top-level mapping of the document read by Load, or nil
+// when there is none
+func (f *File) root() *yaml.Node {
+	if f.doc == nil || f.doc.Kind != yaml.DocumentNode || len(f.doc.Content) == 0 {
+		return nil
+	}
+	if root := f.doc.Content[0]; root.Kind == yaml.MappingNode {
+		return root
+	}
+	return nil
+}
+
+// updateServers replaces the entries of the servers mapping which differ
+// from f.Servers, removes those no longer configured and adds the new ones
+func (f *File) updateServers(root *yaml.Node) error {
+	servers := mappingValue(root, "servers")
+	if servers == nil {
+		servers = &yaml.Node{Kind: yaml.MappingNode}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "servers"}, servers)
+	} else if servers.Kind != yaml.MappingNode {
+		*servers = yaml.Node{Kind: yaml.MappingNode}
+	}
+
+	kept := servers.Content[:0]
+	for i := 0; i+1 < len(servers.Content); i += 2 {
+		if _, ok := f.Servers[servers.Content[i].Value]; ok {
+			kept = append(kept, servers.Content[i], servers.Content[i+1])
+		}
+	}
+	servers.Content = kept
+
+	for _, name := range f.Names() {
+		server := f.Servers[name]
+		existing := mappingValue(servers, name)
+		if existing != nil {
+			var current Server
+			if existing.Decode(&current) == nil && reflect.DeepEqual(current, server) {
+				continue
+			}
+		}
+
+		var value yaml.Node
+		if err := value.Encode(server); err != nil {
+			return err
+		}
+		if existing == nil {
+			servers.Content = append(servers.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, &value)
+			continue
+		}
+		value.HeadComment, value.LineComment, value.FootComment = existing.HeadComment, existing.LineComment, existing.FootComment
+		*existing = value
+	}
+
+	// An empty mapping would be written in flow style as {}
+	if len(servers.Content) > 0 {
+		servers.Style = 0
+	}
+	return nil
+}
+
+// mappingValue returns the value of key in a mapping node, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,317 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Source is a client application whose MCP server configuration can be imported
+type Source string
+
+const (
+	SourceClaudeDesktop Source = "claude-desktop"
+	SourceVSCode        Source = "vscode"
+	SourceCursor        Source = "cursor"
+)
+
+// Sources returns the supported import sources
+func Sources() []Source {
+	return []Source{SourceClaudeDesktop, SourceVSCode, SourceCursor}
+}
+
+// ParseSource validates and returns an import source
+func ParseSource(s string) (Source, error) {
+	for _, source := range Sources() {
+		if Source(s) == source {
+			return source, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported import source: %s (supported: claude-desktop, vscode, cursor)", s)
+}
+
+// DefaultImportPath returns where the source application keeps its
+// configuration. VS Code and Cursor configurations are looked up in the
+// current workspace; for Cursor the global file is used when the workspace
+// has none.
+func DefaultImportPath(source Source) (string, error) {
+	switch source {
+	case SourceClaudeDesktop:
+		var dir string
+		switch runtime.GOOS {
+		case "darwin":
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", err
+			}
+			dir = filepath.Join(home, "Library", "Application Support")
+		case "windows":
+			dir = os.Getenv("APPDATA")
+		default:
+			var err error
+			if dir, err = os.UserConfigDir(); err != nil {
+				return "", err
+			}
+		}
+		return filepath.Join(dir, "Claude", "claude_desktop_config.json"), nil
+	case SourceVSCode:
+		return filepath.Join(".vscode", "mcp.json"), nil
+	case SourceCursor:
+		path := filepath.Join(".cursor", "mcp.json")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, ".cursor", "mcp.json"), nil
+	default:
+		return "", fmt.Errorf("unsupported import source: %s", source)
+	}
+}
+
+// ImportedServer is a server entry converted from another application's
+// configuration
+type ImportedServer struct {
+	Name   string
+	Server Server
+	// Warnings lists what could not be converted
+	Warnings []string
+	// Err is set when the entry cannot be used at all
+	Err error
+}
+
+// importedEntry holds the fields understood in the server entries of all
+// sources
+type importedEntry struct {
+	Type    string            `json:"type"`
+	Command string            `json:"command"`
+	Args    []string          `json:"args"`
+	Env     map[string]string `json:"env"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+}
+
+var importedFields = map[string]bool{
+	"type": true, "command": true, "args": true, "env": true, "url": true, "headers": true,
+}
+
+// Import parses the MCP server configuration of a source application. The
+// servers are returned sorted by name.
+func Import(source Source, data []byte) ([]ImportedServer, error) {
+	var document map[string]json.RawMessage
+	if err := json.Unmarshal(stripJSONC(data), &document); err != nil {
+		return nil, fmt.Errorf("invalid %s configuration: %w", source, err)
+	}
+
+	key := "mcpServers"
+	if source == SourceVSCode {
+		key = "servers"
+	}
+	raw, ok := document[key]
+	if !ok {
+		return nil, fmt.Errorf("invalid %s configuration: no %q object found", source, key)
+	}
+
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, fmt.Errorf("invalid %s configuration: %q must be an object: %w", source, key, err)
+	}
+
+	servers := make([]ImportedServer, 0, len(entries))
+	for name, entry := range entries {
+		servers = append(servers, importServer(source, name, entry))
+	}
+	sort.Slice(servers, func(i, j int) bool { return servers[i].Name < servers[j].Name })
+
+	return servers, nil
+}
+
+// stripJSONC turns the JSON with comments and trailing commas written by VS
+// Code and Cursor into standard JSON. Comments and trailing commas are replaced
+// with spaces, so the offsets of decoding errors still match the file.
+func stripJSONC(data []byte) []byte {
+	out := bytes.Clone(data)
+	inString := false
+	// comma is the offset of a comma not followed by a value yet, or -1
+	comma := -1
+	for i := 0; i < len(out); i++ {
+		c := out[i]
+		if inString {
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString, comma = true, -1
+		case c == '/' && i+1 < len(out) && out[i+1] == '/':
+			for ; i < len(out) && out[i] != '\n'; i++ {
+				out[i] = ' '
+			}
+		case c == '/' && i+1 < len(out) && out[i+1] == '*':
+			end := len(out)
+			if j := bytes.Index(out[i+2:], []byte("*/")); j >= 0 {
+				end = i + 2 + j + 2
+			}
+			for ; i < end; i++ {
+				if out[i] != '\n' {
+					out[i] = ' '
+				}
+			}
+			i--
+		case c == ',':
+			comma = i
+		case c == '}' || c == ']':
+			if comma >= 0 {
+				out[comma] = ' '
+			}
+			comma = -1
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+		default:
+			comma = -1
+		}
+	}
+	return out
+}
+
+func importServer(source Source, name string, raw json.RawMessage) ImportedServer {
+	imported := ImportedServer{Name: name}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		imported.Err = fmt.Errorf("entry must be an object")
+		return imported
+	}
+	var entry importedEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		imported.Err = fmt.Errorf("invalid entry: %w", err)
+		return imported
+	}
+
+	for _, field := range sortedFields(fields) {
+		if !importedFields[field] {
+			imported.Warnings = append(imported.Warnings, fmt.Sprintf("unsupported field %q ignored", field))
+		}
+	}
+
+	transportType, err := importTransportType(entry)
+	if err != nil {
+		imported.Err = err
+		return imported
+	}
+
+	imported.Server = Server{
+		Type:    transportType,
+		Command: entry.Command,
+		Args:    entry.Args,
+		Env:     entry.Env,
+		URL:     entry.URL,
+		Headers: entry.Headers,
+	}
+	// Empty values are dropped like in the saved configuration file, so
+	// entries can be compared with configured ones
+	if len(imported.Server.Args) == 0 {
+		imported.Server.Args = nil
+	}
+	if len(imported.Server.Env) == 0 {
+		imported.Server.Env = nil
+	}
+	if len(imported.Server.Headers) == 0 {
+		imported.Server.Headers = nil
+	}
+	imported.Warnings = append(imported.Warnings, convertVariables(source, &imported.Server)...)
+
+	if err := imported.Server.Validate(); err != nil {
+		imported.Err = err
+	}
+	return imported
+}
+
+// importTransportType maps the transport names used by the applications to
+// adapter types. The type is left empty when it can be derived from the
+// entry, and "http" is mapped to auto detection because the applications
+// fall back to SSE for it.
+func importTransportType(entry importedEntry) (string, error) {
+	switch strings.ToLower(entry.Type) {
+	case "":
+		return "", nil
+	case "stdio":
+		return "stdio", nil
+	case "http":
+		return "auto", nil
+	case "sse":
+		return "sse", nil
+	case "streamable-http", "streamablehttp", "streamable":
+		return "streamable", nil
+	default:
+		return "", fmt.Errorf("unsupported transport type %q", entry.Type)
+	}
+}
+
+var (
+	// VS Code style ${env:NAME} references
+	envVariable = regexp.MustCompile(`\$\{env:([A-Za-z_][A-Za-z0-9_]*)\}`)
+	// Any other ${...} variable (inputs, workspace folders, ...)
+	otherVariable = regexp.MustCompile(`\$\{([^}]*:[^}]*|workspaceFolder[^}]*|userHome)\}`)
+)
+
+// convertVariables rewrites ${env:NAME} references in header values, env
+// values and args to the ${NAME} form expanded by mcp-cli and reports
+// variables that cannot be resolved outside of the source application
+func convertVariables(source Source, server *Server) []string {
+	var warnings []string
+	check := func(field, value string) {
+		if match := otherVariable.FindString(value); match != "" {
+			warnings = append(warnings, fmt.Sprintf("%s contains %s, which is only resolved by %s", field, match, source))
+		}
+	}
+	convert := func(field, value string) string {
+		value = envVariable.ReplaceAllString(value, "$${$1}")
+		check(field, value)
+		return value
+	}
+
+	for _, name := range sortedKeys(server.Headers) {
+		server.Headers[name] = convert("header "+name, server.Headers[name])
+	}
+	for _, name := range sortedKeys(server.Env) {
+		server.Env[name] = convert("env "+name, server.Env[name])
+	}
+	check("command", server.Command)
+	for i, arg := range server.Args {
+		server.Args[i] = convert("args", arg)
+	}
+	check("url", server.URL)
+
+	return warnings
+}
+
+func sortedFields(fields map[string]json.RawMessage) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
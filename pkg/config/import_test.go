@@ -0,0 +1,127 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportClaudeDesktop(t *testing.T) {
+	servers, err := Import(SourceClaudeDesktop, []byte(`{
+		"mcpServers": {
+			"filesystem": {
+				"command": "npx",
+				"args": ["-y", "@modelcontextprotocol/server-filesystem", "/Users/me/Desktop"],
+				"env": {}
+			},
+			"github": {
+				"command": "docker",
+				"args": ["run", "-i", "--rm", "ghcr.io/github/github-mcp-server"],
+				"env": {"GITHUB_PERSONAL_ACCESS_TOKEN": "token"},
+				"disabled": false
+			}
+		},
+		"globalShortcut": "Ctrl+Space"
+	}`))
+	require.NoError(t, err)
+	require.Len(t, servers, 2)
+
+	assert.Equal(t, "filesystem", servers[0].Name)
+	assert.NoError(t, servers[0].Err)
+	assert.Equal(t, Server{
+		Command: "npx",
+		Args:    []string{"-y", "@modelcontextprotocol/server-filesystem", "/Users/me/Desktop"},
+	}, servers[0].Server)
+	assert.Empty(t, servers[0].Warnings)
+
+	assert.Equal(t, "github", servers[1].Name)
+	assert.Equal(t, map[string]string{"GITHUB_PERSONAL_ACCESS_TOKEN": "token"}, servers[1].Server.Env)
+	assert.Equal(t, []string{`unsupported field "disabled" ignored`}, servers[1].Warnings)
+}
+
+func TestImportVSCode(t *testing.T) {
+	servers, err := Import(SourceVSCode, []byte(`{
+		"inputs": [{"type": "promptString", "id": "api-key", "password": true}],
+		"servers": {
+			"remote": {
+				"type": "http",
+				"url": "https://api.example.com/mcp",
+				"headers": {"Authorization": "Bearer ${env:API_TOKEN}", "X-Key": "${input:api-key}"}
+			},
+			"legacy": {"type": "sse", "url": "https://example.com/sse"},
+			"local": {"type": "stdio", "command": "node", "args": ["${workspaceFolder}/server.js"], "envFile": ".env"},
+			"broken": {"type": "websocket", "url": "wss://example.com"},
+			"empty": {"type": "stdio"}
+		}
+	}`))
+	require.NoError(t, err)
+	require.Len(t, servers, 5)
+
+	byName := make(map[string]ImportedServer)
+	for _, server := range servers {
+		byName[server.Name] = server
+	}
+
+	remote := byName["remote"]
+	assert.NoError(t, remote.Err)
+	assert.Equal(t, "auto", remote.Server.Type)
+	assert.Equal(t, "Bearer ${API_TOKEN}", remote.Server.Headers["Authorization"])
+	assert.Equal(t, []string{"header X-Key contains ${input:api-key}, which is only resolved by vscode"}, remote.Warnings)
+
+	assert.Equal(t, "sse", byName["legacy"].Server.Type)
+
+	local := byName["local"]
+	assert.NoError(t, local.Err)
+	assert.Equal(t, []string{
+		`unsupported field "envFile" ignored`,
+		"args contains ${workspaceFolder}, which is only resolved by vscode",
+	}, local.Warnings)
+
+	assert.EqualError(t, byName["broken"].Err, `unsupported transport type "websocket"`)
+	assert.EqualError(t, byName["empty"].Err, "command is required for stdio servers")
+}
+
+func TestImportJSONC(t *testing.T) {
+	data, err := os.ReadFile("testdata/vscode-mcp.jsonc")
+	require.NoError(t, err)
+
+	servers, err := Import(SourceVSCode, data)
+	require.NoError(t, err)
+	require.Len(t, servers, 2)
+
+	local := servers[0]
+	assert.NoError(t, local.Err)
+	assert.Equal(t, []string{"server.js", "--token=${SERVER_TOKEN}", "http://example.com/a//b"}, local.Server.Args)
+	assert.Equal(t, map[string]string{"DEBUG": "/* not a comment */", "API_KEY": "${API_KEY}"}, local.Server.Env)
+	assert.Empty(t, local.Warnings)
+
+	remote := servers[1]
+	assert.NoError(t, remote.Err)
+	assert.Equal(t, "Bearer ${API_TOKEN}", remote.Server.Headers["Authorization"])
+}
+
+func TestImportCursor(t *testing.T) {
+	servers, err := Import(SourceCursor, []byte(`{
+		"mcpServers": {
+			"remote": {"url": "https://example.com/mcp", "headers": {"API_KEY": "value"}}
+		}
+	}`))
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.NoError(t, servers[0].Err)
+	assert.Equal(t, "auto", servers[0].Server.TransportType())
+	assert.Equal(t, map[string]string{"API_KEY": "value"}, servers[0].Server.Headers)
+}
+
+func TestImportErrors(t *testing.T) {
+	_, err := Import(SourceVSCode, []byte(`{"mcpServers": {}}`))
+	assert.ErrorContains(t, err, `no "servers" object found`)
+
+	_, err = Import(SourceCursor, []byte(`not json`))
+	assert.ErrorContains(t, err, "invalid cursor configuration")
+
+	_, err = ParseSource("windsurf")
+	assert.ErrorContains(t, err, "unsupported import source")
+}
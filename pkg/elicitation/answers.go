@@ -0,0 +1,127 @@
+package elicitation
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// Answer is a canned answer of an answers file
+type Answer struct {
+	// Match is a regular expression matched against the message of the
+	// request. An answer without Match answers any request.
+	Match string `yaml:"match,omitempty"`
+
+	// Action is accept, decline or cancel, accept when not set
+	Action mcp.ElicitationResponseAction `yaml:"action,omitempty"`
+
+	// Content holds the values of the fields of an accepted request. Fields
+	// without a value get their default.
+	Content map[string]any `yaml:"content,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+// Answers answers elicitation requests with the first of its answers
+// matching the request
+type Answers struct {
+	Answers []Answer `yaml:"answers"`
+}
+
+// LoadAnswers reads answers from a YAML file:
+//
+//	answers:
+//	  - match: "(?i)github"
+//	    content:
+//	      username: octocat
+//	  - action: decline
+func LoadAnswers(path string) (*Answers, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read elicitation answers: %w", err)
+	}
+
+	answers, err := ParseAnswers(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid elicitation answers %s: %w", path, err)
+	}
+	return answers, nil
+}
+
+// ParseAnswers parses the YAML content of an answers file
+func ParseAnswers(data []byte) (*Answers, error) {
+	var answers Answers
+	if err := yaml.Unmarshal(data, &answers); err != nil {
+		return nil, err
+	}
+	if len(answers.Answers) == 0 {
+		return nil, fmt.Errorf("no answers")
+	}
+
+	for i := range answers.Answers {
+		answer := &answers.Answers[i]
+		switch answer.Action {
+		case "":
+			answer.Action = mcp.ElicitationResponseActionAccept
+		case mcp.ElicitationResponseActionAccept, mcp.ElicitationResponseActionDecline, mcp.ElicitationResponseActionCancel:
+		default:
+			return nil, fmt.Errorf("invalid action of answer %d: %q is not accept, decline or cancel", i+1, answer.Action)
+		}
+
+		if answer.Match == "" {
+			continue
+		}
+		pattern, err := regexp.Compile(answer.Match)
+		if err != nil {
+			return nil, fmt.Errorf("invalid match of answer %d: %w", i+1, err)
+		}
+		answer.pattern = pattern
+	}
+	return &answers, nil
+}
+
+// Elicit implements adapter.ElicitationHandler. The content of accepted
+// requests is checked against the requested schema.
+func (a *Answers) Elicit(ctx context.Context, request mcp.ElicitationRequest) (*mcp.ElicitationResult, error) {
+	if err := CheckMode(request); err != nil {
+		return nil, err
+	}
+
+	message := request.Params.Message
+	for i, answer := range a.Answers {
+		if answer.pattern != nil && !answer.pattern.MatchString(message) {
+			continue
+		}
+
+		result := &mcp.ElicitationResult{
+			ElicitationResponse: mcp.ElicitationResponse{Action: answer.Action},
+		}
+		if answer.Action == mcp.ElicitationResponseActionAccept {
+			fields, err := Fields(request.Params.RequestedSchema)
+			if err != nil {
+				return nil, err
+			}
+			content, err := Content(fields, answer.Content)
+			if err != nil {
+				return nil, fmt.Errorf("answer %d does not match the requested schema: %w", i+1, err)
+			}
+			result.Content = content
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("no answer matches the elicitation request %q", message)
+}
+
+// CheckMode rejects the elicitation requests which are not forms, as only
+// form mode is advertised
+func CheckMode(request mcp.ElicitationRequest) error {
+	if mode := request.Params.Mode; mode != "" && mode != mcp.ElicitationModeForm {
+		return fmt.Errorf("unsupported elicitation mode %s", mode)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package elicitation
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAnswers = `answers:
+  - match: "(?i)profile"
+    content:
+      name: Ada
+      age: 36
+  - match: "(?i)delete"
+    action: decline
+  - match: "(?i)broken"
+    content:
+      name: Ada
+`
+
+func elicitationRequest(message string) mcp.ElicitationRequest {
+	request := mcp.ElicitationRequest{}
+	request.Params.Message = message
+	request.Params.RequestedSchema = testSchema
+	return request
+}
+
+func TestAnswers(t *testing.T) {
+	answers, err := ParseAnswers([]byte(testAnswers))
+	require.NoError(t, err)
+
+	t.Run("accept", func(t *testing.T) {
+		result, err := answers.Elicit(context.Background(), elicitationRequest("Complete your Profile"))
+		require.NoError(t, err)
+		assert.Equal(t, mcp.ElicitationResponseActionAccept, result.Action)
+		assert.Equal(t, map[string]any{"name": "Ada", "age": int64(36), "plan": "free", "subscribe": false}, result.Content)
+	})
+
+	t.Run("decline", func(t *testing.T) {
+		result, err := answers.Elicit(context.Background(), elicitationRequest("Delete the file?"))
+		require.NoError(t, err)
+		assert.Equal(t, mcp.ElicitationResponseActionDecline, result.Action)
+		assert.Nil(t, result.Content)
+	})
+
+	t.Run("content not matching the schema", func(t *testing.T) {
+		_, err := answers.Elicit(context.Background(), elicitationRequest("broken"))
+		assert.EqualError(t, err, "answer 3 does not match the requested schema: missing value of required field age")
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		_, err := answers.Elicit(context.Background(), elicitationRequest("Hello"))
+		assert.EqualError(t, err, `no answer matches the elicitation request "Hello"`)
+	})
+
+	t.Run("url mode", func(t *testing.T) {
+		request := elicitationRequest("Profile")
+		request.Params.Mode = mcp.ElicitationModeURL
+		_, err := answers.Elicit(context.Background(), request)
+		assert.EqualError(t, err, "unsupported elicitation mode url")
+	})
+}
+
+func TestLoadAnswers(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	answers, err := LoadAnswers(write("answers.yaml", testAnswers))
+	require.NoError(t, err)
+	assert.Len(t, answers.Answers, 3)
+	assert.Equal(t, mcp.ElicitationResponseActionAccept, answers.Answers[0].Action)
+
+	_, err = LoadAnswers(write("empty.yaml", ""))
+	assert.ErrorContains(t, err, "no answers")
+
+	_, err = LoadAnswers(write("action.yaml", "answers:\n  - action: maybe\n"))
+	assert.ErrorContains(t, err, `invalid action of answer 1: "maybe" is not accept, decline or cancel`)
+
+	_, err = LoadAnswers(write("match.yaml", "answers:\n  - match: \"(\"\n"))
+	assert.ErrorContains(t, err, "invalid match of answer 1")
+
+	_, err = LoadAnswers(filepath.Join(dir, "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read elicitation answers")
+}
@@ -0,0 +1,385 @@
+package elicitation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Field is a property of the flat object schema requested by a form mode
+// elicitation. Fields hold primitive values: strings, numbers, integers and
+// booleans, single choices from an enum or multiple choices from an enum.
+type Field struct {
+	Name        string
+	Title       string
+	Description string
+
+	// Type is string, number, integer, boolean or array, which is only
+	// used for multiple choices
+	Type     string
+	Format   string
+	Required bool
+	Default  any
+
+	// Options are the allowed values of enums, or the allowed items of
+	// multiple choices
+	Options []Option
+
+	MinLength *int
+	MaxLength *int
+	Minimum   *float64
+	Maximum   *float64
+	MinItems  *int
+	MaxItems  *int
+}
+
+// Option is an allowed value of an enum with its display title
+type Option struct {
+	Value string
+	Title string
+}
+
+// property is the JSON schema of a field
+type property struct {
+	Type        string       `json:"type"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Format      string       `json:"format"`
+	Default     any          `json:"default"`
+	Enum        []any        `json:"enum"`
+	EnumNames   []string     `json:"enumNames"`
+	OneOf       []constTitle `json:"oneOf"`
+	AnyOf       []constTitle `json:"anyOf"`
+	Items       *property    `json:"items"`
+	MinLength   *int         `json:"minLength"`
+	MaxLength   *int         `json:"maxLength"`
+	Minimum     *float64     `json:"minimum"`
+	Maximum     *float64     `json:"maximum"`
+	MinItems    *int         `json:"minItems"`
+	MaxItems    *int         `json:"maxItems"`
+}
+
+// constTitle is an enum value of a titled enum, declared with oneOf or anyOf
+type constTitle struct {
+	Const any    `json:"const"`
+	Title string `json:"title"`
+}
+
+// Fields returns the fields of a requested schema. Required fields come first
+// in the order they are declared as required, followed by the optional fields
+// sorted by name, as the order of the properties is not kept in transit.
+func Fields(requestedSchema any) ([]Field, error) {
+	data, err := json.Marshal(requestedSchema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid requested schema: %w", err)
+	}
+	var schema struct {
+		Type       string              `json:"type"`
+		Properties map[string]property `json:"properties"`
+		Required   []string            `json:"required"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("invalid requested schema: %w", err)
+	}
+	if schema.Type != "" && schema.Type != "object" {
+		return nil, fmt.Errorf("invalid requested schema: type %s is not an object", schema.Type)
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		if !slices.Contains(schema.Required, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var required []string
+	for _, name := range schema.Required {
+		if _, ok := schema.Properties[name]; ok && !slices.Contains(required, name) {
+			required = append(required, name)
+		}
+	}
+	names = append(required, names...)
+
+	fields := make([]Field, 0, len(names))
+	for _, name := range names {
+		field, err := newField(name, schema.Properties[name])
+		if err != nil {
+			return nil, err
+		}
+		field.Required = slices.Contains(required, name)
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func newField(name string, p property) (Field, error) {
+	field := Field{
+		Name:        name,
+		Title:       p.Title,
+		Description: p.Description,
+		Type:        p.Type,
+		Format:      p.Format,
+		Default:     p.Default,
+		MinLength:   p.MinLength,
+		MaxLength:   p.MaxLength,
+		Minimum:     p.Minimum,
+		Maximum:     p.Maximum,
+		MinItems:    p.MinItems,
+		MaxItems:    p.MaxItems,
+	}
+
+	switch p.Type {
+	case "string", "number", "integer", "boolean":
+		field.Options = options(p)
+	case "array":
+		if p.Items == nil {
+			return Field{}, fmt.Errorf("invalid schema of %s: array without items", name)
+		}
+		field.Options = options(*p.Items)
+		if len(field.Options) == 0 {
+			return Field{}, fmt.Errorf("invalid schema of %s: only arrays of enum values are supported", name)
+		}
+	default:
+		return Field{}, fmt.Errorf("invalid schema of %s: unsupported type %q", name, p.Type)
+	}
+	return field, nil
+}
+
+// options returns the enum values of a property, with their titles
+func options(p property) []Option {
+	var options []Option
+	for i, value := range p.Enum {
+		option := Option{Value: fmt.Sprint(value)}
+		if i < len(p.EnumNames) {
+			option.Title = p.EnumNames[i]
+		}
+		options = append(options, option)
+	}
+	for _, titled := range append(p.OneOf, p.AnyOf...) {
+		options = append(options, Option{Value: fmt.Sprint(titled.Const), Title: titled.Title})
+	}
+	return options
+}
+
+// Label returns the title of the field, or its name
+func (f Field) Label() string {
+	if f.Title != "" {
+		return f.Title
+	}
+	return f.Name
+}
+
+// Parse converts a value typed by the user. Multiple choices are separated
+// by commas.
+func (f Field) Parse(input string) (any, error) {
+	input = strings.TrimSpace(input)
+	switch f.Type {
+	case "array":
+		items := []any{}
+		for _, item := range strings.Split(input, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return f.Validate(items)
+	case "boolean":
+		switch strings.ToLower(input) {
+		case "y", "yes", "true":
+			return true, nil
+		case "n", "no", "false":
+			return false, nil
+		}
+		return nil, fmt.Errorf("%q is not yes or no", input)
+	case "integer":
+		n, err := strconv.ParseInt(input, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", input)
+		}
+		return f.Validate(n)
+	case "number":
+		n, err := strconv.ParseFloat(input, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", input)
+		}
+		return f.Validate(n)
+	default:
+		return f.Validate(input)
+	}
+}
+
+// Validate checks a value against the schema of the field and returns it
+// with the JSON type of the field
+func (f Field) Validate(value any) (any, error) {
+	switch f.Type {
+	case "array":
+		return f.validateItems(value)
+	case "boolean":
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%v is not a boolean", value)
+		}
+		return b, nil
+	case "integer", "number":
+		return f.validateNumber(value)
+	default:
+		return f.validateString(value)
+	}
+}
+
+func (f Field) validateString(value any) (any, error) {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case time.Time:
+		// Dates are decoded as timestamps from YAML
+		s = v.Format(time.RFC3339)
+		if f.Format == "date" {
+			s = v.Format(time.DateOnly)
+		}
+	default:
+		return nil, fmt.Errorf("%v is not a string", value)
+	}
+
+	if err := f.checkOption(s); err != nil {
+		return nil, err
+	}
+	length := len([]rune(s))
+	if f.MinLength != nil && length < *f.MinLength {
+		return nil, fmt.Errorf("%q is shorter than %d characters", s, *f.MinLength)
+	}
+	if f.MaxLength != nil && length > *f.MaxLength {
+		return nil, fmt.Errorf("%q is longer than %d characters", s, *f.MaxLength)
+	}
+
+	var err error
+	switch f.Format {
+	case "email":
+		_, err = mail.ParseAddress(s)
+	case "uri":
+		var u *url.URL
+		if u, err = url.Parse(s); err == nil && !u.IsAbs() {
+			err = fmt.Errorf("not absolute")
+		}
+	case "date":
+		_, err = time.Parse(time.DateOnly, s)
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, s)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a valid %s", s, f.Format)
+	}
+	return s, nil
+}
+
+func (f Field) validateNumber(value any) (any, error) {
+	var n float64
+	switch v := value.(type) {
+	case int:
+		n = float64(v)
+	case int64:
+		n = float64(v)
+	case float64:
+		n = v
+	default:
+		return nil, fmt.Errorf("%v is not a number", value)
+	}
+
+	if f.Type == "integer" && n != math.Trunc(n) {
+		return nil, fmt.Errorf("%v is not an integer", value)
+	}
+	if err := f.checkOption(strconv.FormatFloat(n, 'f', -1, 64)); err != nil {
+		return nil, err
+	}
+	if f.Minimum != nil && n < *f.Minimum {
+		return nil, fmt.Errorf("%v is less than %v", value, *f.Minimum)
+	}
+	if f.Maximum != nil && n > *f.Maximum {
+		return nil, fmt.Errorf("%v is greater than %v", value, *f.Maximum)
+	}
+
+	if f.Type == "integer" {
+		return int64(n), nil
+	}
+	return n, nil
+}
+
+func (f Field) validateItems(value any) (any, error) {
+	list, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%v is not a list", value)
+	}
+
+	items := make([]string, 0, len(list))
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			s = fmt.Sprint(item)
+		}
+		if err := f.checkOption(s); err != nil {
+			return nil, err
+		}
+		items = append(items, s)
+	}
+	if f.MinItems != nil && len(items) < *f.MinItems {
+		return nil, fmt.Errorf("at least %d choices are required", *f.MinItems)
+	}
+	if f.MaxItems != nil && len(items) > *f.MaxItems {
+		return nil, fmt.Errorf("at most %d choices are allowed", *f.MaxItems)
+	}
+	return items, nil
+}
+
+// checkOption checks that a value is one of the options of an enum
+func (f Field) checkOption(value string) error {
+	if len(f.Options) == 0 {
+		return nil
+	}
+	values := make([]string, len(f.Options))
+	for i, option := range f.Options {
+		if option.Value == value {
+			return nil
+		}
+		values[i] = option.Value
+	}
+	return fmt.Errorf("%q is not one of %s", value, strings.Join(values, ", "))
+}
+
+// Content validates the values of the fields and returns the content of an
+// accepted elicitation. Missing values are set to their default, and an error
+// is returned when a required field has neither.
+func Content(fields []Field, values map[string]any) (map[string]any, error) {
+	for name := range values {
+		if !slices.ContainsFunc(fields, func(f Field) bool { return f.Name == name }) {
+			return nil, fmt.Errorf("unknown field %s", name)
+		}
+	}
+
+	content := make(map[string]any, len(fields))
+	for _, field := range fields {
+		value, ok := values[field.Name]
+		if !ok || value == nil {
+			value = field.Default
+		}
+		if value == nil {
+			if field.Required {
+				return nil, fmt.Errorf("missing value of required field %s", field.Name)
+			}
+			continue
+		}
+
+		validated, err := field.Validate(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of %s: %w", field.Name, err)
+		}
+		content[field.Name] = validated
+	}
+	return content, nil
+}
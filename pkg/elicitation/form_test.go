@@ -0,0 +1,123 @@
+package elicitation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSchema is a requested schema as decoded from JSON
+var testSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"name":  map[string]any{"type": "string", "title": "Full name", "minLength": 2.0},
+		"email": map[string]any{"type": "string", "format": "email"},
+		"age":   map[string]any{"type": "integer", "minimum": 0.0, "maximum": 150.0},
+		"plan": map[string]any{
+			"type":      "string",
+			"enum":      []any{"free", "pro"},
+			"enumNames": []any{"Free", "Professional"},
+			"default":   "free",
+		},
+		"size": map[string]any{
+			"type":  "string",
+			"oneOf": []any{map[string]any{"const": "s", "title": "Small"}, map[string]any{"const": "l", "title": "Large"}},
+		},
+		"tags": map[string]any{
+			"type":  "array",
+			"items": map[string]any{"type": "string", "enum": []any{"a", "b", "c"}},
+		},
+		"subscribe": map[string]any{"type": "boolean", "default": false},
+	},
+	"required": []any{"name", "age"},
+}
+
+func TestFields(t *testing.T) {
+	fields, err := Fields(testSchema)
+	require.NoError(t, err)
+
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+	}
+	assert.Equal(t, []string{"name", "age", "email", "plan", "size", "subscribe", "tags"}, names)
+
+	assert.True(t, fields[0].Required)
+	assert.Equal(t, "Full name", fields[0].Label())
+	assert.False(t, fields[2].Required)
+	assert.Equal(t, "email", fields[2].Label())
+	assert.Equal(t, []Option{{Value: "free", Title: "Free"}, {Value: "pro", Title: "Professional"}}, fields[3].Options)
+	assert.Equal(t, []Option{{Value: "s", Title: "Small"}, {Value: "l", Title: "Large"}}, fields[4].Options)
+	assert.Equal(t, "array", fields[6].Type)
+	assert.Len(t, fields[6].Options, 3)
+
+	_, err = Fields(map[string]any{"type": "object", "properties": map[string]any{"x": map[string]any{"type": "object"}}})
+	assert.ErrorContains(t, err, `unsupported type "object"`)
+}
+
+func TestFieldParse(t *testing.T) {
+	fields, err := Fields(testSchema)
+	require.NoError(t, err)
+	field := make(map[string]Field)
+	for _, f := range fields {
+		field[f.Name] = f
+	}
+
+	tests := []struct {
+		field   string
+		input   string
+		want    any
+		wantErr string
+	}{
+		{field: "name", input: " Ada ", want: "Ada"},
+		{field: "name", input: "A", wantErr: "shorter than 2 characters"},
+		{field: "age", input: "36", want: int64(36)},
+		{field: "age", input: "3.5", wantErr: "not an integer"},
+		{field: "age", input: "200", wantErr: "greater than 150"},
+		{field: "email", input: "ada@example.com", want: "ada@example.com"},
+		{field: "email", input: "ada", wantErr: "not a valid email"},
+		{field: "plan", input: "pro", want: "pro"},
+		{field: "plan", input: "gold", wantErr: `"gold" is not one of free, pro`},
+		{field: "subscribe", input: "yes", want: true},
+		{field: "subscribe", input: "N", want: false},
+		{field: "subscribe", input: "maybe", wantErr: "not yes or no"},
+		{field: "tags", input: "a, c", want: []string{"a", "c"}},
+		{field: "tags", input: "a,d", wantErr: `"d" is not one of a, b, c`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field+" "+tt.input, func(t *testing.T) {
+			got, err := field[tt.field].Parse(tt.input)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestContent(t *testing.T) {
+	fields, err := Fields(testSchema)
+	require.NoError(t, err)
+
+	content, err := Content(fields, map[string]any{"name": "Ada", "age": 36, "tags": []any{"b"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"name":      "Ada",
+		"age":       int64(36),
+		"plan":      "free",
+		"subscribe": false,
+		"tags":      []string{"b"},
+	}, content)
+
+	_, err = Content(fields, map[string]any{"name": "Ada"})
+	assert.EqualError(t, err, "missing value of required field age")
+
+	_, err = Content(fields, map[string]any{"name": "Ada", "age": "old"})
+	assert.EqualError(t, err, "invalid value of age: old is not a number")
+
+	_, err = Content(fields, map[string]any{"name": "Ada", "age": 1, "nickname": "A"})
+	assert.EqualError(t, err, "unknown field nickname")
+}
@@ -0,0 +1,297 @@
+package inputs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jbovet/mcp-cli/pkg/models"
+)
+
+// Mask replaces secret values in output
+const Mask = "****"
+
+// Provider supplies the values of inputs, e.g. from command line flags or by
+// asking the user
+type Provider interface {
+	// Provide returns the values supplied for the named input. Repeated
+	// inputs accept several values, and no values means none was supplied.
+	Provide(name string, input models.Input, repeated bool) ([]string, error)
+}
+
+// Values are input values supplied by name
+type Values map[string][]string
+
+// ParseValues parses name=value pairs. Names given several times get several
+// values, for repeated inputs.
+func ParseValues(pairs []string) (Values, error) {
+	values := make(Values, len(pairs))
+	for _, pair := range pairs {
+		name, value, found := strings.Cut(pair, "=")
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid input %q, expected name=value", pair)
+		}
+		values[name] = append(values[name], value)
+	}
+	return values, nil
+}
+
+// Provide implements Provider
+func (v Values) Provide(name string, input models.Input, repeated bool) ([]string, error) {
+	return v[name], nil
+}
+
+// Providers asks each provider in turn until one supplies values
+type Providers []Provider
+
+// Provide implements Provider
+func (p Providers) Provide(name string, input models.Input, repeated bool) ([]string, error) {
+	for _, provider := range p {
+		values, err := provider.Provide(name, input, repeated)
+		if err != nil || len(values) > 0 {
+			return values, err
+		}
+	}
+	return nil, nil
+}
+
+// Resolver evaluates registry input metadata into concrete values. Values
+// come from a provider, fall back to defaults and are validated against the
+// input format and choices. The secrets resolved are remembered so they can
+// be masked in output.
+type Resolver struct {
+	provider Provider
+	secrets  []string
+}
+
+// NewResolver creates a resolver taking values from provider
+func NewResolver(provider Provider) *Resolver {
+	return &Resolver{provider: provider}
+}
+
+// Value resolves an input with variables. Inputs with a fixed value use it
+// as a template where each {variable} is substituted; others take the value
+// supplied by the provider. An empty result means the optional input was
+// left unset.
+func (r *Resolver) Value(name string, input models.InputWithVariables) (string, error) {
+	values, err := r.values(name, input, false)
+	if err != nil || len(values) == 0 {
+		return "", err
+	}
+	return values[0], nil
+}
+
+// Arguments resolves registry arguments into command line arguments.
+// Positional arguments contribute their value and named arguments their name
+// followed by the value, unless the value already starts with "name=" (as
+// in a "--port={port}" template). Named boolean arguments without a fixed
+// value are flags, only passed when true. Repeated arguments are passed once
+// per value, and arguments left unset are omitted.
+func (r *Resolver) Arguments(arguments []models.Argument) ([]string, error) {
+	var args []string
+	for i, argument := range arguments {
+		values, err := r.values(ArgumentName(argument, i), argument.InputWithVariables, argument.IsRepeated)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, value := range values {
+			switch {
+			case argument.Type != models.ArgumentTypeNamed:
+				args = append(args, value)
+			case argument.Format == models.FormatBoolean && argument.Value == "":
+				if value == "true" {
+					args = append(args, argument.Name)
+				}
+			case strings.HasPrefix(value, argument.Name+"="):
+				args = append(args, value)
+			default:
+				args = append(args, argument.Name, value)
+			}
+		}
+	}
+	return args, nil
+}
+
+// Environment resolves environment variable inputs. Variables left unset
+// are omitted.
+func (r *Resolver) Environment(variables []models.KeyValueInput) (map[string]string, error) {
+	env := make(map[string]string, len(variables))
+	for _, variable := range variables {
+		value, err := r.Value(variable.Name, variable.InputWithVariables)
+		if err != nil {
+			return nil, err
+		}
+		if value != "" {
+			env[variable.Name] = value
+		}
+	}
+	return env, nil
+}
+
+// Secrets returns the secret values resolved so far
+func (r *Resolver) Secrets() []string {
+	return slices.Clone(r.secrets)
+}
+
+// Mask hides the secrets resolved so far in s
+func (r *Resolver) Mask(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, Mask)
+	}
+	return s
+}
+
+// MaskArgs hides the secrets resolved so far in command line arguments
+func (r *Resolver) MaskArgs(args []string) []string {
+	if args == nil {
+		return nil
+	}
+	masked := make([]string, len(args))
+	for i, arg := range args {
+		masked[i] = r.Mask(arg)
+	}
+	return masked
+}
+
+// MaskMap hides the secrets resolved so far in the values of env or headers
+func (r *Resolver) MaskMap(values map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+	masked := make(map[string]string, len(values))
+	for key, value := range values {
+		masked[key] = r.Mask(value)
+	}
+	return masked
+}
+
+// ArgumentName returns the name an argument is supplied and asked for by:
+// the name of named arguments without leading dashes, or the value hint of
+// positional arguments. i is the position of the argument, used for
+// positional arguments without a hint.
+func ArgumentName(argument models.Argument, i int) string {
+	if argument.Type == models.ArgumentTypeNamed && argument.Name != "" {
+		return strings.TrimLeft(argument.Name, "-")
+	}
+	if argument.ValueHint != "" {
+		return argument.ValueHint
+	}
+	if argument.Name != "" {
+		return argument.Name
+	}
+	return fmt.Sprintf("argument_%d", i+1)
+}
+
+// Validate checks a value against the format and choices of an input and
+// returns it normalized: booleans as true or false, and file paths with a
+// leading ~ expanded
+func Validate(name string, input models.Input, value string) (string, error) {
+	if len(input.Choices) > 0 && !slices.Contains(input.Choices, value) {
+		return "", fmt.Errorf("invalid value for %s: must be one of %s", name, strings.Join(input.Choices, ", "))
+	}
+
+	switch input.Format {
+	case models.FormatNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "", fmt.Errorf("invalid value for %s: %q is not a number", name, value)
+		}
+	case models.FormatBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid value for %s: %q is not a boolean", name, value)
+		}
+		value = strconv.FormatBool(b)
+	case models.FormatFilePath:
+		if value == "~" || strings.HasPrefix(value, "~"+string(filepath.Separator)) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", fmt.Errorf("failed to locate home directory: %w", err)
+			}
+			value = filepath.Join(home, value[1:])
+		}
+		if _, err := os.Stat(value); err != nil {
+			return "", fmt.Errorf("invalid value for %s: %s does not exist", name, value)
+		}
+	}
+
+	return value, nil
+}
+
+// values resolves the values of an input, several for repeated inputs
+func (r *Resolver) values(name string, input models.InputWithVariables, repeated bool) ([]string, error) {
+	var values []string
+	if input.Value != "" {
+		values = []string{input.Value}
+	} else {
+		supplied, err := r.provider.Provide(name, input.Input, repeated)
+		if err != nil {
+			return nil, err
+		}
+		if len(supplied) == 0 && input.Default != "" {
+			supplied = []string{input.Default}
+		}
+		if len(supplied) > 1 && !repeated {
+			return nil, fmt.Errorf("%s accepts a single value", name)
+		}
+
+		for _, value := range supplied {
+			if value == "" {
+				continue
+			}
+			value, err := Validate(name, input.Input, value)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+	}
+
+	if len(values) == 0 {
+		if input.IsRequired {
+			return nil, fmt.Errorf("%s is required", name)
+		}
+		return nil, nil
+	}
+	if input.IsSecret {
+		r.addSecrets(values...)
+	}
+
+	variables := make([]string, 0, len(input.Variables))
+	for variable := range input.Variables {
+		variables = append(variables, variable)
+	}
+	sort.Strings(variables)
+	for _, variable := range variables {
+		placeholder := "{" + variable + "}"
+		if !slices.ContainsFunc(values, func(value string) bool { return strings.Contains(value, placeholder) }) {
+			continue
+		}
+
+		value, err := r.Value(variable, models.InputWithVariables{Input: input.Variables[variable]})
+		if err != nil {
+			return nil, err
+		}
+		// A template is left out when one of its optional variables is unset
+		if value == "" {
+			return nil, nil
+		}
+		for i := range values {
+			values[i] = strings.ReplaceAll(values[i], placeholder, value)
+		}
+	}
+
+	return values, nil
+}
+
+func (r *Resolver) addSecrets(secrets ...string) {
+	for _, secret := range secrets {
+		if secret != "" && !slices.Contains(r.secrets, secret) {
+			r.secrets = append(r.secrets, secret)
+		}
+	}
+}
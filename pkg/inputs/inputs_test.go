@@ -0,0 +1,194 @@
+package inputs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jbovet/mcp-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withInput(input models.Input) models.InputWithVariables {
+	return models.InputWithVariables{Input: input}
+}
+
+// providerFunc adapts a function to the Provider interface
+type providerFunc func(name string, input models.Input, repeated bool) ([]string, error)
+
+func (f providerFunc) Provide(name string, input models.Input, repeated bool) ([]string, error) {
+	return f(name, input, repeated)
+}
+
+func TestParseValues(t *testing.T) {
+	values, err := ParseValues([]string{"port=8080", "dir=/a", "dir=/b", "empty=", "expr=a=b"})
+	require.NoError(t, err)
+	assert.Equal(t, Values{
+		"port":  {"8080"},
+		"dir":   {"/a", "/b"},
+		"empty": {""},
+		"expr":  {"a=b"},
+	}, values)
+
+	_, err = ParseValues([]string{"port"})
+	assert.ErrorContains(t, err, `invalid input "port", expected name=value`)
+}
+
+func TestProviders(t *testing.T) {
+	var asked []string
+	prompt := providerFunc(func(name string, input models.Input, repeated bool) ([]string, error) {
+		asked = append(asked, name)
+		return []string{"answer"}, nil
+	})
+
+	providers := Providers{Values{"given": {"value"}}, prompt}
+	values, err := providers.Provide("given", models.Input{}, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"value"}, values)
+
+	values, err = providers.Provide("other", models.Input{}, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"answer"}, values)
+	assert.Equal(t, []string{"other"}, asked)
+
+	failing := providerFunc(func(string, models.Input, bool) ([]string, error) { return nil, errors.New("no terminal") })
+	_, err = Providers{failing, prompt}.Provide("x", models.Input{}, false)
+	assert.EqualError(t, err, "no terminal")
+}
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "data.db")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+
+	tests := []struct {
+		name     string
+		input    models.Input
+		value    string
+		expected string
+		err      string
+	}{
+		{name: "string", input: models.Input{}, value: "anything", expected: "anything"},
+		{name: "number", input: models.Input{Format: models.FormatNumber}, value: "8080", expected: "8080"},
+		{name: "decimal", input: models.Input{Format: models.FormatNumber}, value: "0.5", expected: "0.5"},
+		{name: "not a number", input: models.Input{Format: models.FormatNumber}, value: "many", err: `invalid value for x: "many" is not a number`},
+		{name: "boolean", input: models.Input{Format: models.FormatBoolean}, value: "1", expected: "true"},
+		{name: "not a boolean", input: models.Input{Format: models.FormatBoolean}, value: "yes", err: `invalid value for x: "yes" is not a boolean`},
+		{name: "file path", input: models.Input{Format: models.FormatFilePath}, value: file, expected: file},
+		{name: "missing file", input: models.Input{Format: models.FormatFilePath}, value: filepath.Join(dir, "missing"), err: "does not exist"},
+		{name: "choice", input: models.Input{Choices: []string{"a", "b"}}, value: "b", expected: "b"},
+		{name: "invalid choice", input: models.Input{Choices: []string{"a", "b"}}, value: "c", err: "invalid value for x: must be one of a, b"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, err := Validate("x", test.input, test.value)
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, value)
+		})
+	}
+
+	t.Run("home directory", func(t *testing.T) {
+		t.Setenv("HOME", dir)
+		value, err := Validate("x", models.Input{Format: models.FormatFilePath}, "~/data.db")
+		require.NoError(t, err)
+		assert.Equal(t, file, value)
+	})
+}
+
+func TestResolverValue(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		value, err := NewResolver(Values{}).Value("region", withInput(models.Input{Default: "us"}))
+		require.NoError(t, err)
+		assert.Equal(t, "us", value)
+	})
+
+	t.Run("required", func(t *testing.T) {
+		_, err := NewResolver(Values{"token": {""}}).Value("token", withInput(models.Input{IsRequired: true}))
+		assert.EqualError(t, err, "token is required")
+	})
+
+	t.Run("single value", func(t *testing.T) {
+		_, err := NewResolver(Values{"port": {"1", "2"}}).Value("port", withInput(models.Input{}))
+		assert.EqualError(t, err, "port accepts a single value")
+	})
+
+	t.Run("template", func(t *testing.T) {
+		input := models.InputWithVariables{
+			Input: models.Input{Value: "Bearer {token}"},
+			Variables: map[string]models.Input{
+				"token":  {IsRequired: true, IsSecret: true},
+				"unused": {IsRequired: true},
+			},
+		}
+		resolver := NewResolver(Values{"token": {"s3cr3t"}})
+		value, err := resolver.Value("Authorization", input)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer s3cr3t", value)
+		assert.Equal(t, []string{"s3cr3t"}, resolver.Secrets())
+	})
+
+	t.Run("template with unset optional variable", func(t *testing.T) {
+		input := models.InputWithVariables{
+			Input:     models.Input{Value: "--port={port}"},
+			Variables: map[string]models.Input{"port": {Format: models.FormatNumber}},
+		}
+		value, err := NewResolver(Values{}).Value("port", input)
+		require.NoError(t, err)
+		assert.Empty(t, value)
+	})
+
+	t.Run("invalid variable", func(t *testing.T) {
+		input := models.InputWithVariables{
+			Input:     models.Input{Value: "--port={port}"},
+			Variables: map[string]models.Input{"port": {Format: models.FormatNumber}},
+		}
+		_, err := NewResolver(Values{"port": {"http"}}).Value("port", input)
+		assert.EqualError(t, err, `invalid value for port: "http" is not a number`)
+	})
+}
+
+func TestResolverArguments(t *testing.T) {
+	arguments := []models.Argument{
+		{Type: models.ArgumentTypePositional, ValueHint: "target_dir", IsRepeated: true, InputWithVariables: withInput(models.Input{})},
+		{Type: models.ArgumentTypeNamed, Name: "--port", InputWithVariables: models.InputWithVariables{
+			Input:     models.Input{Value: "--port={port}"},
+			Variables: map[string]models.Input{"port": {Default: "8080", Format: models.FormatNumber}},
+		}},
+		{Type: models.ArgumentTypeNamed, Name: "--tag", IsRepeated: true, InputWithVariables: withInput(models.Input{})},
+		{Type: models.ArgumentTypeNamed, Name: "--read-only", InputWithVariables: withInput(models.Input{Format: models.FormatBoolean})},
+		{Type: models.ArgumentTypeNamed, Name: "--debug", InputWithVariables: withInput(models.Input{Format: models.FormatBoolean})},
+		{Type: models.ArgumentTypeNamed, Name: "--api-key", InputWithVariables: withInput(models.Input{IsSecret: true})},
+		{Type: models.ArgumentTypePositional, InputWithVariables: withInput(models.Input{})},
+	}
+	values := Values{
+		"target_dir": {"/a", "/b"},
+		"tag":        {"x", "y"},
+		"read-only":  {"true"},
+		"debug":      {"false"},
+		"api-key":    {"k3y"},
+		"argument_7": {"last"},
+	}
+
+	resolver := NewResolver(values)
+	args, err := resolver.Arguments(arguments)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"/a", "/b",
+		"--port=8080",
+		"--tag", "x", "--tag", "y",
+		"--read-only",
+		"--api-key", "k3y",
+		"last",
+	}, args)
+
+	assert.Equal(t, []string{"--api-key", Mask}, resolver.MaskArgs([]string{"--api-key", "k3y"}))
+	assert.Equal(t, map[string]string{"KEY": "prefix-" + Mask}, resolver.MaskMap(map[string]string{"KEY": "prefix-k3y"}))
+	assert.Nil(t, resolver.MaskArgs(nil))
+}
@@ -0,0 +1,120 @@
+package inputs
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/jbovet/mcp-cli/pkg/models"
+)
+
+// Command is the command line running a registry package
+type Command struct {
+	Command string
+	Args    []string
+	Env     map[string]string
+}
+
+// runtimes maps package registries to the command running their packages
+var runtimes = map[string]string{
+	"npm":    "npx",
+	"pypi":   "uvx",
+	"docker": "docker",
+	"nuget":  "dnx",
+}
+
+// Registries returns the package registries with a known runtime
+func Registries() []string {
+	return []string{"npm", "pypi", "docker", "nuget"}
+}
+
+// Runtime returns the command running a package: its runtime hint, or the
+// runtime of its registry
+func Runtime(pkg models.Package) (string, error) {
+	if pkg.RuntimeHint != "" {
+		return pkg.RuntimeHint, nil
+	}
+	if runtime, ok := runtimes[pkg.RegistryName]; ok {
+		return runtime, nil
+	}
+	return "", fmt.Errorf("unsupported package registry %q", pkg.RegistryName)
+}
+
+// Package resolves the inputs of a registry package into the command line
+// running it:
+//   - npm:    npx [runtime args] name@version [package args]
+//   - pypi:   uvx [runtime args] name==version [package args]
+//   - docker: docker run -i --rm [runtime args] -e NAME... name:version [package args]
+//   - nuget:  dnx [runtime args] name@version --yes -- [package args]
+//
+// npx gets -y when the package declares no runtime arguments, and docker
+// containers get the environment passed through from the docker process.
+func (r *Resolver) Package(pkg models.Package) (*Command, error) {
+	command, err := Runtime(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	runtimeArgs, err := r.Arguments(pkg.RuntimeArguments)
+	if err != nil {
+		return nil, err
+	}
+	packageArgs, err := r.Arguments(pkg.PackageArguments)
+	if err != nil {
+		return nil, err
+	}
+	env, err := r.Environment(pkg.EnvironmentVariables)
+	if err != nil {
+		return nil, err
+	}
+
+	version := pkg.Version
+	if version == "latest" {
+		version = ""
+	}
+
+	var args []string
+	switch pkg.RegistryName {
+	case "pypi":
+		args = append(runtimeArgs, versioned(pkg.Name, "==", version))
+		args = append(args, packageArgs...)
+	case "docker":
+		args = []string{"run"}
+		for _, flag := range []string{"-i", "--rm"} {
+			if !slices.Contains(runtimeArgs, flag) {
+				args = append(args, flag)
+			}
+		}
+		args = append(args, runtimeArgs...)
+		for _, variable := range pkg.EnvironmentVariables {
+			if _, set := env[variable.Name]; set {
+				args = append(args, "-e", variable.Name)
+			}
+		}
+		args = append(args, versioned(pkg.Name, ":", version))
+		args = append(args, packageArgs...)
+	case "nuget":
+		args = append(runtimeArgs, versioned(pkg.Name, "@", version), "--yes")
+		if len(packageArgs) > 0 {
+			args = append(append(args, "--"), packageArgs...)
+		}
+	default:
+		// npm and packages run through their runtime hint
+		if len(runtimeArgs) == 0 && command == "npx" {
+			runtimeArgs = []string{"-y"}
+		}
+		args = append(runtimeArgs, versioned(pkg.Name, "@", version))
+		args = append(args, packageArgs...)
+	}
+
+	if len(env) == 0 {
+		env = nil
+	}
+	return &Command{Command: command, Args: args, Env: env}, nil
+}
+
+func versioned(name, separator, version string) string {
+	if version == "" {
+		return name
+	}
+	return name + separator + version
+}
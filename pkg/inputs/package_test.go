@@ -0,0 +1,122 @@
+package inputs
+
+import (
+	"testing"
+
+	"github.com/jbovet/mcp-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolverPackage(t *testing.T) {
+	tests := []struct {
+		name     string
+		pkg      models.Package
+		values   Values
+		expected Command
+	}{
+		{
+			name: "npm",
+			pkg: models.Package{
+				RegistryName: "npm",
+				Name:         "@modelcontextprotocol/server-filesystem",
+				Version:      "1.0.2",
+				PackageArguments: []models.Argument{
+					{Type: models.ArgumentTypePositional, ValueHint: "directory", IsRepeated: true, InputWithVariables: withInput(models.Input{IsRequired: true})},
+				},
+			},
+			values: Values{"directory": {"/tmp", "/var/tmp"}},
+			expected: Command{
+				Command: "npx",
+				Args:    []string{"-y", "@modelcontextprotocol/server-filesystem@1.0.2", "/tmp", "/var/tmp"},
+			},
+		},
+		{
+			name: "pypi",
+			pkg: models.Package{
+				RegistryName: "pypi",
+				Name:         "mcp-server-time",
+				Version:      "0.6.2",
+				PackageArguments: []models.Argument{
+					{Type: models.ArgumentTypeNamed, Name: "--local-timezone", InputWithVariables: withInput(models.Input{Default: "UTC"})},
+					{Type: models.ArgumentTypeNamed, Name: "--verbose", InputWithVariables: withInput(models.Input{Format: models.FormatBoolean})},
+				},
+			},
+			values: Values{"verbose": {"false"}},
+			expected: Command{
+				Command: "uvx",
+				Args:    []string{"mcp-server-time==0.6.2", "--local-timezone", "UTC"},
+			},
+		},
+		{
+			name: "docker",
+			pkg: models.Package{
+				RegistryName: "docker",
+				Name:         "ghcr.io/github/github-mcp-server",
+				Version:      "latest",
+				RuntimeArguments: []models.Argument{
+					{
+						Type: models.ArgumentTypeNamed,
+						Name: "--mount",
+						InputWithVariables: models.InputWithVariables{
+							Input:     models.Input{Value: "type=bind,src={source},dst=/data"},
+							Variables: map[string]models.Input{"source": {IsRequired: true}},
+						},
+					},
+				},
+				EnvironmentVariables: []models.KeyValueInput{
+					{Name: "GITHUB_TOKEN", InputWithVariables: withInput(models.Input{IsRequired: true, IsSecret: true})},
+					{Name: "GITHUB_HOST", InputWithVariables: withInput(models.Input{})},
+				},
+			},
+			values: Values{"source": {"/home/me"}, "GITHUB_TOKEN": {"ghp_secret"}},
+			expected: Command{
+				Command: "docker",
+				Args:    []string{"run", "-i", "--rm", "--mount", "type=bind,src=/home/me,dst=/data", "-e", "GITHUB_TOKEN", "ghcr.io/github/github-mcp-server"},
+				Env:     map[string]string{"GITHUB_TOKEN": "ghp_secret"},
+			},
+		},
+		{
+			name: "nuget",
+			pkg: models.Package{
+				RegistryName: "nuget",
+				Name:         "Contoso.Mcp",
+				Version:      "2.0.0",
+				PackageArguments: []models.Argument{
+					{Type: models.ArgumentTypeNamed, Name: "--mode", InputWithVariables: withInput(models.Input{Choices: []string{"read", "write"}, Default: "read"})},
+				},
+			},
+			values: Values{"mode": {"write"}},
+			expected: Command{
+				Command: "dnx",
+				Args:    []string{"Contoso.Mcp@2.0.0", "--yes", "--", "--mode", "write"},
+			},
+		},
+		{
+			name: "runtime hint",
+			pkg: models.Package{
+				RegistryName:     "npm",
+				Name:             "server-everything",
+				RuntimeHint:      "bunx",
+				RuntimeArguments: []models.Argument{{Type: models.ArgumentTypeNamed, Name: "--bun", InputWithVariables: withInput(models.Input{Value: "true"})}},
+			},
+			expected: Command{
+				Command: "bunx",
+				Args:    []string{"--bun", "true", "server-everything"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			command, err := NewResolver(test.values).Package(test.pkg)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, *command)
+		})
+	}
+
+	t.Run("unsupported registry", func(t *testing.T) {
+		_, err := NewResolver(Values{}).Package(models.Package{RegistryName: "cargo", Name: "x"})
+		assert.EqualError(t, err, `unsupported package registry "cargo"`)
+	})
+}
@@ -43,9 +43,9 @@ type Package struct {
 
 // Remote represents a remote connection endpoint
 type Remote struct {
-	TransportType string  `json:"transport_type"`
-	URL           string  `json:"url"`
-	Headers       []Input `json:"headers,omitempty"`
+	TransportType string          `json:"transport_type"`
+	URL           string          `json:"url"`
+	Headers       []KeyValueInput `json:"headers,omitempty"`
 }
 
 // Format represents input format types
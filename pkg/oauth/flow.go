@@ -0,0 +1,184 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+)
+
+// ClientName is the name used for dynamic client registration
+const ClientName = "mcp-cli"
+
+// Options configures the authorization flow for servers requiring OAuth
+type Options struct {
+	// ClientID and ClientSecret of a pre-registered client. When empty, the
+	// client is registered dynamically.
+	ClientID     string
+	ClientSecret string
+
+	// Scopes to request
+	Scopes []string
+
+	// CacheDir holds the token cache, DefaultCacheDir() when empty
+	CacheDir string
+
+	// OpenURL presents the authorization URL to the user, e.g. by launching
+	// a browser
+	OpenURL func(authURL string) error
+}
+
+// callbackPath is the path of the loopback redirect URI
+const callbackPath = "/callback"
+
+type callbackResult struct {
+	code  string
+	state string
+	err   error
+}
+
+// Listener receives the authorization response on a loopback redirect URI
+type Listener struct {
+	listener    net.Listener
+	server      *http.Server
+	redirectURI string
+	results     chan callbackResult
+}
+
+// Listen starts a loopback listener for the redirect URI. The port of
+// previousRedirectURI is reused when it is still available, so a client
+// registered for it stays valid; otherwise a random port is used.
+func Listen(previousRedirectURI string) (*Listener, error) {
+	var listener net.Listener
+	if previous, err := url.Parse(previousRedirectURI); err == nil && previous.Port() != "" {
+		listener, _ = net.Listen("tcp", net.JoinHostPort("127.0.0.1", previous.Port()))
+	}
+	if listener == nil {
+		var err error
+		if listener, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+			return nil, fmt.Errorf("failed to start redirect listener: %w", err)
+		}
+	}
+
+	l := &Listener{
+		listener:    listener,
+		redirectURI: fmt.Sprintf("http://%s%s", listener.Addr().String(), callbackPath),
+		results:     make(chan callbackResult, 1),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(callbackPath, l.handleCallback)
+	l.server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = l.server.Serve(listener) }()
+
+	return l, nil
+}
+
+// RedirectURI returns the redirect URI served by the listener
+func (l *Listener) RedirectURI() string {
+	return l.redirectURI
+}
+
+// Wait blocks until the authorization response is received
+func (l *Listener) Wait(ctx context.Context) (code, state string, err error) {
+	select {
+	case result := <-l.results:
+		return result.code, result.state, result.err
+	case <-ctx.Done():
+		return "", "", fmt.Errorf("timed out waiting for authorization: %w", ctx.Err())
+	}
+}
+
+// Close stops the listener
+func (l *Listener) Close() error {
+	err := l.server.Close()
+	// The server may not have taken over the listener yet
+	if closeErr := l.listener.Close(); err == nil && !errors.Is(closeErr, net.ErrClosed) {
+		err = closeErr
+	}
+	return err
+}
+
+func (l *Listener) handleCallback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	result := callbackResult{code: query.Get("code"), state: query.Get("state")}
+	if errorCode := query.Get("error"); errorCode != "" {
+		result.err = fmt.Errorf("authorization failed: %s", errorCode)
+		if description := query.Get("error_description"); description != "" {
+			result.err = fmt.Errorf("authorization failed: %s: %s", errorCode, description)
+		}
+	} else if result.code == "" {
+		result.err = errors.New("authorization failed: no authorization code received")
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if result.err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintf(w, "%v\n", result.err)
+	} else {
+		_, _ = fmt.Fprintln(w, "Authorization complete. You can close this window and return to mcp-cli.")
+	}
+
+	// Only the first response is used
+	select {
+	case l.results <- result:
+	default:
+	}
+}
+
+// Authorize runs the authorization code flow with PKCE for a server that
+// rejected the client. The client is registered dynamically when handler has
+// no client ID, and the obtained token is saved to the handler's token store.
+// The user has to complete the authorization before ctx is done.
+func Authorize(ctx context.Context, handler *transport.OAuthHandler, store *TokenStore, listener *Listener, options Options) error {
+	if options.OpenURL == nil {
+		return errors.New("server requires authorization but no way to open the authorization URL is configured")
+	}
+
+	if handler.GetClientID() == "" {
+		if err := handler.RegisterClient(ctx, ClientName); err != nil {
+			return fmt.Errorf("failed to register client: %w", err)
+		}
+		registration := Registration{
+			ClientID:     handler.GetClientID(),
+			ClientSecret: handler.GetClientSecret(),
+			RedirectURI:  listener.RedirectURI(),
+		}
+		if err := store.SaveRegistration(registration); err != nil {
+			return err
+		}
+	}
+
+	codeVerifier, err := transport.GenerateCodeVerifier()
+	if err != nil {
+		return fmt.Errorf("failed to generate code verifier: %w", err)
+	}
+	state, err := transport.GenerateState()
+	if err != nil {
+		return fmt.Errorf("failed to generate state: %w", err)
+	}
+
+	authURL, err := handler.GetAuthorizationURL(ctx, state, transport.GenerateCodeChallenge(codeVerifier))
+	if err != nil {
+		return fmt.Errorf("failed to build authorization URL: %w", err)
+	}
+	if err := options.OpenURL(authURL); err != nil {
+		return err
+	}
+
+	code, receivedState, err := listener.Wait(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := handler.ProcessAuthorizationResponse(ctx, code, receivedState, codeVerifier); err != nil {
+		return fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	return nil
+}
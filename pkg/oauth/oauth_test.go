@@ -0,0 +1,126 @@
+package oauth
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenStore(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := NewTokenStore(dir, "https://example.com/mcp")
+	require.NoError(t, err)
+	assert.False(t, store.HasToken())
+
+	_, err = store.GetToken(context.Background())
+	assert.ErrorIs(t, err, ErrNoToken)
+
+	registration, err := store.Registration()
+	require.NoError(t, err)
+	assert.Nil(t, registration)
+
+	t.Run("token and registration are persisted", func(t *testing.T) {
+		require.NoError(t, store.SaveRegistration(Registration{ClientID: "client", RedirectURI: "http://127.0.0.1:1234/callback"}))
+		require.NoError(t, store.SaveToken(context.Background(), &transport.Token{AccessToken: "access", TokenType: "Bearer", RefreshToken: "refresh"}))
+
+		reopened, err := NewTokenStore(dir, "https://example.com/mcp")
+		require.NoError(t, err)
+		assert.True(t, reopened.HasToken())
+
+		token, err := reopened.GetToken(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "access", token.AccessToken)
+		assert.Equal(t, "refresh", token.RefreshToken)
+
+		registration, err := reopened.Registration()
+		require.NoError(t, err)
+		assert.Equal(t, "client", registration.ClientID)
+	})
+
+	t.Run("entries are keyed by server URL", func(t *testing.T) {
+		other, err := NewTokenStore(dir, "https://example.com/other")
+		require.NoError(t, err)
+		assert.NotEqual(t, store.Path(), other.Path())
+		assert.False(t, other.HasToken())
+	})
+
+	t.Run("new registration drops the token", func(t *testing.T) {
+		require.NoError(t, store.SaveRegistration(Registration{ClientID: "another-client"}))
+		assert.False(t, store.HasToken())
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		require.NoError(t, store.Clear())
+		require.NoError(t, store.Clear())
+		registration, err := store.Registration()
+		require.NoError(t, err)
+		assert.Nil(t, registration)
+	})
+}
+
+func TestListener(t *testing.T) {
+	t.Run("receives the authorization code", func(t *testing.T) {
+		listener, err := Listen("")
+		require.NoError(t, err)
+		defer func() { _ = listener.Close() }()
+
+		redirect, err := url.Parse(listener.RedirectURI())
+		require.NoError(t, err)
+		assert.Equal(t, "127.0.0.1", redirect.Hostname())
+		assert.Equal(t, "/callback", redirect.Path)
+
+		resp, err := http.Get(listener.RedirectURI() + "?code=abc&state=xyz")
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		code, state, err := listener.Wait(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "abc", code)
+		assert.Equal(t, "xyz", state)
+	})
+
+	t.Run("reports authorization errors", func(t *testing.T) {
+		listener, err := Listen("")
+		require.NoError(t, err)
+		defer func() { _ = listener.Close() }()
+
+		resp, err := http.Get(listener.RedirectURI() + "?error=access_denied&error_description=denied+by+user")
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		_, _, err = listener.Wait(context.Background())
+		assert.EqualError(t, err, "authorization failed: access_denied: denied by user")
+	})
+
+	t.Run("reuses the previous port", func(t *testing.T) {
+		first, err := Listen("")
+		require.NoError(t, err)
+		redirectURI := first.RedirectURI()
+		require.NoError(t, first.Close())
+
+		second, err := Listen(redirectURI)
+		require.NoError(t, err)
+		defer func() { _ = second.Close() }()
+		assert.Equal(t, redirectURI, second.RedirectURI())
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		listener, err := Listen("")
+		require.NoError(t, err)
+		defer func() { _ = listener.Close() }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, _, err = listener.Wait(ctx)
+		assert.ErrorContains(t, err, "timed out waiting for authorization")
+	})
+}
@@ -0,0 +1,206 @@
+package oauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+)
+
+// ErrNoToken is returned by TokenStore.GetToken when no token is cached. It
+// is the error the OAuth handler of the transport checks for.
+var ErrNoToken = transport.ErrNoToken
+
+// Registration holds the client registered with an authorization server
+type Registration struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret,omitempty"`
+	RedirectURI  string `json:"redirect_uri,omitempty"`
+}
+
+// cacheEntry is the on-disk representation of the cached credentials of a server
+type cacheEntry struct {
+	ServerURL    string           `json:"server_url"`
+	Registration *Registration    `json:"registration,omitempty"`
+	Token        *transport.Token `json:"token,omitempty"`
+}
+
+// TokenStore implements transport.TokenStore on top of a file cache. Each
+// server URL gets its own file holding the token and the dynamically
+// registered client, so tokens can be refreshed in later sessions.
+type TokenStore struct {
+	path      string
+	serverURL string
+	mu        sync.Mutex
+}
+
+// DefaultCacheDir returns the directory of the token cache
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate cache directory: %w", err)
+	}
+	return filepath.Join(dir, "mcp-cli", "oauth"), nil
+}
+
+// NewTokenStore creates a token store for serverURL in dir. The default
+// cache directory is used when dir is empty.
+func NewTokenStore(dir, serverURL string) (*TokenStore, error) {
+	if dir == "" {
+		var err error
+		if dir, err = DefaultCacheDir(); err != nil {
+			return nil, err
+		}
+	}
+
+	sum := sha256.Sum256([]byte(serverURL))
+	return &TokenStore{
+		path:      filepath.Join(dir, hex.EncodeToString(sum[:16])+".json"),
+		serverURL: serverURL,
+	}, nil
+}
+
+// Path returns the cache file of the store
+func (s *TokenStore) Path() string {
+	return s.path
+}
+
+// GetToken returns the cached token
+func (s *TokenStore) GetToken(ctx context.Context) (*transport.Token, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	if entry.Token == nil {
+		return nil, ErrNoToken
+	}
+	return entry.Token, nil
+}
+
+// SaveToken caches a token
+func (s *TokenStore) SaveToken(ctx context.Context, token *transport.Token) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, err := s.read()
+	if err != nil {
+		return err
+	}
+	entry.Token = token
+	return s.write(entry)
+}
+
+// HasToken returns whether a token is cached, even if it has expired
+func (s *TokenStore) HasToken() bool {
+	token, err := s.GetToken(context.Background())
+	return err == nil && (token.AccessToken != "" || token.RefreshToken != "")
+}
+
+// Registration returns the cached client registration, or nil when the
+// client has not been registered yet
+func (s *TokenStore) Registration() (*Registration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return entry.Registration, nil
+}
+
+// SaveRegistration caches a client registration. The cached token is
+// dropped as it was issued to the previous client.
+func (s *TokenStore) SaveRegistration(registration Registration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, err := s.read()
+	if err != nil {
+		return err
+	}
+	if entry.Registration == nil || entry.Registration.ClientID != registration.ClientID {
+		entry.Token = nil
+	}
+	entry.Registration = &registration
+	return s.write(entry)
+}
+
+// Clear removes the cached credentials of the server
+func (s *TokenStore) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove token cache: %w", err)
+	}
+	return nil
+}
+
+func (s *TokenStore) read() (*cacheEntry, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &cacheEntry{ServerURL: s.serverURL}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token cache: %w", err)
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to decode token cache %s: %w", s.path, err)
+	}
+	// Guard against hash collisions
+	if entry.ServerURL != s.serverURL {
+		return &cacheEntry{ServerURL: s.serverURL}, nil
+	}
+	return &entry, nil
+}
+
+// write replaces the cache file atomically. The file holds credentials, so
+// it is only readable by the current user.
+func (s *TokenStore) write(entry *cacheEntry) error {
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode token cache: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create token cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".token-*")
+	if err != nil {
+		return fmt.Errorf("failed to write token cache: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write token cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write token cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write token cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format represents an output format
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatWide  Format = "wide"
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+)
+
+// SupportedFormats returns the list of supported output formats
+func SupportedFormats() []Format {
+	return []Format{FormatTable, FormatWide, FormatJSON, FormatYAML}
+}
+
+// ParseFormat validates and returns an output format
+func ParseFormat(s string) (Format, error) {
+	format := Format(strings.ToLower(s))
+	for _, supported := range SupportedFormats() {
+		if format == supported {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported output format: %s (supported: table, wide, json, yaml)", s)
+}
+
+// IsStructured returns whether the format is a machine-readable serialization
+func (f Format) IsStructured() bool {
+	return f == FormatJSON || f == FormatYAML
+}
+
+// Write serializes v in the given structured format. The JSON field names of
+// the value are used for both JSON and YAML so the two stay consistent.
+func Write(w io.Writer, format Format, v any) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(v)
+	case FormatYAML:
+		return writeYAML(w, v)
+	default:
+		return fmt.Errorf("format %s is not a structured output format", format)
+	}
+}
+
+// writeYAML converts v to YAML through its JSON representation, which keeps
+// the JSON field names and their order
+func writeYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	resetStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	return encoder.Close()
+}
+
+// resetStyle switches the JSON flow style nodes to block style. Strings that
+// would change type when unquoted (e.g. "true", "1") are still quoted by the
+// encoder.
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
@@ -0,0 +1,76 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sample struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Enabled     string            `json:"enabled"`
+	Count       int               `json:"count"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Items       []string          `json:"items"`
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, name := range []string{"table", "wide", "json", "yaml", "JSON"} {
+		format, err := ParseFormat(name)
+		require.NoError(t, err)
+		assert.Contains(t, SupportedFormats(), format)
+	}
+
+	_, err := ParseFormat("xml")
+	assert.Error(t, err)
+}
+
+func TestIsStructured(t *testing.T) {
+	assert.True(t, FormatJSON.IsStructured())
+	assert.True(t, FormatYAML.IsStructured())
+	assert.False(t, FormatTable.IsStructured())
+	assert.False(t, FormatWide.IsStructured())
+}
+
+func TestWrite(t *testing.T) {
+	value := sample{
+		Name:        "server",
+		Description: "A description that is definitely longer than the sixty characters used by tables",
+		Enabled:     "true",
+		Count:       2,
+		Items:       []string{"a", "b"},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, FormatJSON, value))
+		assert.JSONEq(t, `{
+			"name": "server",
+			"description": "A description that is definitely longer than the sixty characters used by tables",
+			"enabled": "true",
+			"count": 2,
+			"items": ["a", "b"]
+		}`, buf.String())
+	})
+
+	t.Run("yaml keeps JSON field names and order", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, FormatYAML, value))
+		assert.Equal(t, `name: server
+description: A description that is definitely longer than the sixty characters used by tables
+enabled: "true"
+count: 2
+items:
+  - a
+  - b
+`, buf.String())
+	})
+
+	t.Run("table is not structured", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.Error(t, Write(&buf, FormatTable, value))
+	})
+}
@@ -0,0 +1,125 @@
+package sampling
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultModel is the model reported for scripted responses
+	DefaultModel = "mcp-cli-script"
+
+	// DefaultStopReason is the stop reason reported for scripted responses
+	DefaultStopReason = "endTurn"
+)
+
+// Response is a canned response of a script
+type Response struct {
+	// Match is a regular expression matched against the text of the
+	// request messages, one per line. A response without Match answers any
+	// request.
+	Match string `yaml:"match,omitempty"`
+
+	// Text is the content of the response
+	Text string `yaml:"text"`
+
+	// Model and StopReason are reported with the response
+	Model      string `yaml:"model,omitempty"`
+	StopReason string `yaml:"stop_reason,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+// Script answers sampling requests with the first of its responses matching
+// the request
+type Script struct {
+	Responses []Response `yaml:"responses"`
+}
+
+// LoadScript reads a script from a YAML file:
+//
+//	responses:
+//	  - match: "(?i)capital of france"
+//	    text: Paris
+//	  - text: I don't know
+func LoadScript(path string) (*Script, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sampling script: %w", err)
+	}
+
+	script, err := ParseScript(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sampling script %s: %w", path, err)
+	}
+	return script, nil
+}
+
+// ParseScript parses the YAML content of a script
+func ParseScript(data []byte) (*Script, error) {
+	var script Script
+	if err := yaml.Unmarshal(data, &script); err != nil {
+		return nil, err
+	}
+	if len(script.Responses) == 0 {
+		return nil, fmt.Errorf("no responses")
+	}
+
+	for i := range script.Responses {
+		response := &script.Responses[i]
+		if response.Match == "" {
+			continue
+		}
+		pattern, err := regexp.Compile(response.Match)
+		if err != nil {
+			return nil, fmt.Errorf("invalid match of response %d: %w", i+1, err)
+		}
+		response.pattern = pattern
+	}
+	return &script, nil
+}
+
+// CreateMessage implements adapter.SamplingHandler
+func (s *Script) CreateMessage(ctx context.Context, request mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error) {
+	text := messagesText(request.Messages)
+	for _, response := range s.Responses {
+		if response.pattern != nil && !response.pattern.MatchString(text) {
+			continue
+		}
+
+		result := &mcp.CreateMessageResult{
+			SamplingMessage: mcp.SamplingMessage{
+				Role:    mcp.RoleAssistant,
+				Content: mcp.NewTextContent(response.Text),
+			},
+			Model:      response.Model,
+			StopReason: response.StopReason,
+		}
+		if result.Model == "" {
+			result.Model = DefaultModel
+		}
+		if result.StopReason == "" {
+			result.StopReason = DefaultStopReason
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("no scripted response matches the sampling request %q", text)
+}
+
+// messagesText joins the text contents of the messages, one per line
+func messagesText(messages []mcp.SamplingMessage) string {
+	var texts []string
+	for _, message := range messages {
+		if content, ok := message.Content.(mcp.TextContent); ok {
+			texts = append(texts, content.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
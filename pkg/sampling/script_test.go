@@ -0,0 +1,86 @@
+package sampling
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testScript = `responses:
+  - match: "(?i)capital of france"
+    text: Paris
+    model: geography-1
+  - match: "(?m)^summarize"
+    text: A short summary.
+    stop_reason: maxTokens
+`
+
+func samplingRequest(texts ...string) mcp.CreateMessageRequest {
+	request := mcp.CreateMessageRequest{}
+	for _, text := range texts {
+		request.Messages = append(request.Messages, mcp.SamplingMessage{Role: mcp.RoleUser, Content: mcp.NewTextContent(text)})
+	}
+	return request
+}
+
+func TestScript(t *testing.T) {
+	script, err := ParseScript([]byte(testScript))
+	require.NoError(t, err)
+
+	t.Run("first match", func(t *testing.T) {
+		result, err := script.CreateMessage(context.Background(), samplingRequest("What is the Capital of France?"))
+		require.NoError(t, err)
+		assert.Equal(t, mcp.RoleAssistant, result.Role)
+		assert.Equal(t, mcp.NewTextContent("Paris"), result.Content)
+		assert.Equal(t, "geography-1", result.Model)
+		assert.Equal(t, DefaultStopReason, result.StopReason)
+	})
+
+	t.Run("match on any message", func(t *testing.T) {
+		result, err := script.CreateMessage(context.Background(), samplingRequest("Hello", "summarize this"))
+		require.NoError(t, err)
+		assert.Equal(t, mcp.NewTextContent("A short summary."), result.Content)
+		assert.Equal(t, DefaultModel, result.Model)
+		assert.Equal(t, "maxTokens", result.StopReason)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		_, err := script.CreateMessage(context.Background(), samplingRequest("Hello"))
+		assert.EqualError(t, err, `no scripted response matches the sampling request "Hello"`)
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		script, err := ParseScript([]byte(testScript + "  - text: I don't know\n"))
+		require.NoError(t, err)
+		result, err := script.CreateMessage(context.Background(), samplingRequest("Hello"))
+		require.NoError(t, err)
+		assert.Equal(t, mcp.NewTextContent("I don't know"), result.Content)
+	})
+}
+
+func TestLoadScript(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	script, err := LoadScript(write("script.yaml", testScript))
+	require.NoError(t, err)
+	assert.Len(t, script.Responses, 2)
+
+	_, err = LoadScript(write("empty.yaml", ""))
+	assert.ErrorContains(t, err, "no responses")
+
+	_, err = LoadScript(write("invalid.yaml", "responses:\n  - match: \"(\"\n    text: x\n"))
+	assert.ErrorContains(t, err, "invalid match of response 1")
+
+	_, err = LoadScript(filepath.Join(dir, "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read sampling script")
+}